@@ -0,0 +1,104 @@
+// Command todo-server runs a reference implementation of the todo API used
+// by the custom example provider, for local development, demos and testing
+// how the provider copes with a misbehaving server.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"terraform-provider-custom-example/internal/todoserver"
+)
+
+func main() {
+	var (
+		addr     string
+		dataFile string
+		opts     todoserver.Options
+		faults   todoserver.Faults
+
+		trimTitles      bool
+		lowercaseTitles bool
+	)
+
+	flag.StringVar(&addr, "addr", ":8080", "address to listen on")
+	flag.StringVar(&dataFile, "data", "", "file the todo list is persisted to; kept in memory when empty")
+	flag.StringVar(&opts.Username, "username", "", "require this username with Basic auth or token login; no authentication when empty")
+	flag.StringVar(&opts.Password, "password", "", "password required with -username (default $TODO_SERVER_PASSWORD)")
+	flag.BoolVar(&opts.DisableQuery, "disable-query", false, "ignore filter, sort and paging parameters when listing, like older servers")
+	flag.IntVar(&opts.PageSize, "page-size", 0, "paginate listings with this many items per page unless the client asks for a page size")
+	flag.BoolVar(&opts.LinkPagination, "link-pagination", false, "announce the next page in a Link header instead of a response envelope")
+	flag.BoolVar(&opts.BareTitles, "bare-titles", false, "return lists as arrays of titles without item IDs, like older servers")
+	flag.BoolVar(&trimTitles, "trim-titles", false, "strip leading and trailing whitespace from stored titles")
+	flag.BoolVar(&lowercaseTitles, "lowercase-titles", false, "lowercase stored titles")
+	flag.IntVar(&opts.MaxTitleLength, "max-title-length", 0, "reject titles with more characters; unlimited when 0")
+	flag.DurationVar(&faults.Latency, "latency", 0, "delay added to every request")
+	flag.Float64Var(&faults.ErrorRate, "error-rate", 0, "share of requests answered with 500, between 0 and 1")
+	flag.Float64Var(&faults.ThrottleRate, "throttle-rate", 0, "share of requests answered with 429, between 0 and 1")
+	flag.DurationVar(&faults.RetryAfter, "retry-after", time.Second, "Retry-After sent with injected 429 responses, rounded up to whole seconds")
+	flag.Float64Var(&faults.TimeoutRate, "timeout-rate", 0, "share of requests that hang, between 0 and 1")
+	flag.DurationVar(&faults.HangFor, "hang-for", 5*time.Minute, "how long hanging requests wait before answering 504")
+	flag.Parse()
+
+	// Read after parsing so -h does not print the password as the default
+	if opts.Password == "" {
+		opts.Password = os.Getenv("TODO_SERVER_PASSWORD")
+	}
+
+	if trimTitles || lowercaseTitles {
+		opts.TitleTransform = func(title string) string {
+			if trimTitles {
+				title = strings.TrimSpace(title)
+			}
+			if lowercaseTitles {
+				title = strings.ToLower(title)
+			}
+			return title
+		}
+	}
+
+	var (
+		srv *todoserver.Server
+		err error
+	)
+	if dataFile != "" {
+		srv, err = todoserver.Open(dataFile, opts)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	} else {
+		srv = todoserver.New(opts)
+	}
+
+	handler := logRequests(todoserver.WithFaults(srv, faults))
+
+	log.Printf("todo server listening on %s", addr)
+	err = http.ListenAndServe(addr, handler)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.Path, rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
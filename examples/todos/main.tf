@@ -14,7 +14,17 @@ provider "customexample"{
 }
 
 resource customexample_add_todo_items "addingtodos"{
-	todo_list=["A","B","C"]
+	# Leave the item managed by customexample_todo_item.single alone
+	mode = "additive"
+	todo_list=[
+		{ title = "A" },
+		{ title = "B", description = "Second item", priority = 1, tags = ["home"] },
+		{ title = "C", done = true, due_at = "2024-05-01T09:30:00Z" },
+	]
+}
+
+resource customexample_todo_item "single"{
+	title = "D"
 }
 
 data customexample_todo "todo"{
@@ -23,4 +33,16 @@ data customexample_todo "todo"{
 
 output "todolist"{
 	value = data.customexample_todo.todo
-}
\ No newline at end of file
+}
+
+data customexample_todo "open_home_todos"{
+	tag     = "home"
+	done    = false
+	sort_by = "priority"
+	limit   = 5
+}
+
+data customexample_todo_item "first"{
+	index      = 0
+	depends_on = [ customexample_add_todo_items.addingtodos ]
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-custom-example/internal/todoclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addClientError adds a diagnostic for an error returned by the todo API
+// client. API errors are mapped to a diagnostic per class of status code
+// telling users where to look; other errors, such as network failures, are
+// reported under summary.
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *todoclient.Error
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	detail := summary + ": " + err.Error()
+
+	switch code := apiErr.StatusCode; {
+	case code == http.StatusBadRequest:
+		diags.AddError(
+			"Invalid Todo API Request",
+			"The todo API could not process the request. Check the configured values.\n\n"+detail,
+		)
+	case code == http.StatusUnauthorized:
+		diags.AddError(
+			"Todo API Authentication Failed",
+			"The todo API rejected the configured credentials. Check the provider username and password "+
+				"or the CUSTOM_EXAMPLE_USERNAME and CUSTOM_EXAMPLE_PASSWORD environment variables.\n\n"+detail,
+		)
+	case code == http.StatusForbidden:
+		diags.AddError(
+			"Todo API Access Denied",
+			"The configured user is not allowed to perform this operation on the todo API.\n\n"+detail,
+		)
+	case code == http.StatusNotFound:
+		diags.AddError(
+			"Todo API Object Not Found",
+			"The requested todo list or item does not exist. It may have been deleted outside of Terraform, "+
+				"or the provider baseurl may point at the wrong server.\n\n"+detail,
+		)
+	case code == http.StatusConflict:
+		diags.AddError(
+			"Todo API Conflict",
+			"The request conflicts with the current state on the server, usually because it was changed concurrently. "+
+				"Refresh the state and apply again.\n\n"+detail,
+		)
+	case code == http.StatusUnprocessableEntity:
+		diags.AddError(
+			"Todo Items Rejected",
+			"The todo API rejected the configured items as invalid. Fix the reported values and apply again.\n\n"+detail,
+		)
+	case code == http.StatusTooManyRequests:
+		diags.AddError(
+			"Todo API Rate Limit Exceeded",
+			"The todo API kept rejecting requests as too frequent after all retries. "+
+				"Increase max_retries or retry_wait_max on the provider, or lower Terraform parallelism.\n\n"+detail,
+		)
+	case code >= 500:
+		diags.AddError(
+			"Todo API Server Error",
+			"The todo API failed to handle the request. This is usually temporary; try again later "+
+				"and contact the API operators with the request ID if the problem persists.\n\n"+detail,
+		)
+	default:
+		diags.AddError(summary, err.Error())
+	}
+}
+
+// addItemErrors adds an attribute error for every item that the API
+// rejected individually, at the path itemPath returns for its position in
+// the request, so Terraform points at the offending element. It reports
+// whether err carried such item errors.
+func addItemErrors(diags *diag.Diagnostics, itemPath itemPathFunc, err error) bool {
+	var apiErr *todoclient.Error
+	if !errors.As(err, &apiErr) || len(apiErr.Items) == 0 {
+		return false
+	}
+
+	for _, item := range apiErr.Items {
+		diags.AddAttributeError(
+			itemPath(item.Index),
+			"Todo Item Rejected",
+			fmt.Sprintf("The todo API rejected this item: %s", item.Message),
+		)
+	}
+	return true
+}
@@ -2,14 +2,18 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
+	"terraform-provider-custom-example/internal/todoclient"
+	"terraform-provider-custom-example/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -25,12 +29,32 @@ func NewGetToDoDataSource() datasource.DataSource {
 
 // GetToDoDataSource is the data source implementation.
 type GetToDoDataSource struct {
-	baseurl string
+	client *todoclient.Client
 }
 
 // GetToDoDataSourceModel maps the data source schema data.
 type ToDoDataSourceModel struct {
-	TodoList []string `tfsdk:"todo_list"`
+	Title       types.String              `tfsdk:"title"`
+	TitlePrefix types.String              `tfsdk:"title_prefix"`
+	TitleRegex  types.String              `tfsdk:"title_regex"`
+	Tag         types.String              `tfsdk:"tag"`
+	Done        types.Bool                `tfsdk:"done"`
+	SortBy      types.String              `tfsdk:"sort_by"`
+	Limit       types.Int64               `tfsdk:"limit"`
+	Offset      types.Int64               `tfsdk:"offset"`
+	TodoList    []todoDataSourceItemModel `tfsdk:"todo_list"`
+}
+
+// todoDataSourceItemModel maps a todo object of the data source, which
+// unlike the resource exposes the server assigned ID.
+type todoDataSourceItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       titleValue   `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Done        types.Bool   `tfsdk:"done"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	DueAt       types.String `tfsdk:"due_at"`
+	Tags        []string     `tfsdk:"tags"`
 }
 
 // Metadata returns the data source type name.
@@ -41,10 +65,91 @@ func (d *GetToDoDataSource) Metadata(_ context.Context, req datasource.MetadataR
 // Schema defines the schema for the data source.
 func (d *GetToDoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Reads the todo list, optionally filtered, sorted and paged. " +
+			"Filters are combined and applied by the server when it supports them, by the provider otherwise.",
 		Attributes: map[string]schema.Attribute{
-			"todo_list": schema.ListAttribute{
-				ElementType: types.StringType,
+			"title": schema.StringAttribute{
+				Description: "Only return items with exactly this title.",
+				Optional:    true,
+			},
+			"title_prefix": schema.StringAttribute{
+				Description: "Only return items whose title starts with this prefix.",
+				Optional:    true,
+			},
+			"title_regex": schema.StringAttribute{
+				Description: "Only return items whose title matches this RE2 regular expression.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexpValidator{},
+				},
+			},
+			"tag": schema.StringAttribute{
+				Description: "Only return items carrying this tag.",
+				Optional:    true,
+			},
+			"done": schema.BoolAttribute{
+				Description: "Only return completed items when true, open items when false.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Ascending order of the returned items: " +
+					"\"server\" (default), \"title\", \"priority\" or \"due_at\", which puts items without a due date last.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(todoclient.SortByServer, todoclient.SortByTitle, todoclient.SortByPriority, todoclient.SortByDueAt),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: "Maximum number of items to return.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"offset": schema.Int64Attribute{
+				Description: "Number of matching items to skip before returning any.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"todo_list": schema.ListNestedAttribute{
+				Description: "Items of the todo list matching the filters, in order.",
 				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Identifier of the item, assigned by the server.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Text of the todo item.",
+							CustomType:  titleType{},
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Longer description of the todo item.",
+							Computed:    true,
+						},
+						"done": schema.BoolAttribute{
+							Description: "Whether the todo item is completed.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the todo item.",
+							Computed:    true,
+						},
+						"due_at": schema.StringAttribute{
+							Description: "Due date of the todo item as an RFC 3339 timestamp, if any.",
+							Computed:    true,
+						},
+						"tags": schema.ListAttribute{
+							Description: "Tags of the todo item.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -52,40 +157,54 @@ func (d *GetToDoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *GetToDoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state ToDoDataSourceModel
+	ctx, span := tracing.Start(ctx, "data.customexample_todo.Read")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
 
-	res, err := http.Get(fmt.Sprintf("%s/get", d.baseurl))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Hit the Todo List get endpoint",
-			err.Error(),
-		)
+	var state ToDoDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body to model
-	defer res.Body.Close()
-	bodyBytes, err := io.ReadAll(res.Body)
+	query := todoclient.Query{
+		Title:       state.Title.ValueString(),
+		TitlePrefix: state.TitlePrefix.ValueString(),
+		TitleRegex:  state.TitleRegex.ValueString(),
+		Tag:         state.Tag.ValueString(),
+		SortBy:      state.SortBy.ValueString(),
+		Limit:       int(state.Limit.ValueInt64()),
+		Offset:      int(state.Offset.ValueInt64()),
+	}
+	if !state.Done.IsNull() {
+		done := state.Done.ValueBool()
+		query.Done = &done
+	}
+
+	todoList, err := d.client.Query(ctx, query)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read the response body",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Unable to Read Todo List", err)
 		return
 	}
 
-	err = json.Unmarshal(bodyBytes, &state.TodoList)
+	tflog.Debug(ctx, "Read todo list", map[string]any{"items": len(todoList)})
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read/Unmarshal Todo List",
-			err.Error(),
-		)
-		return
+	// Map response body to model
+	state.TodoList = make([]todoDataSourceItemModel, 0, len(todoList))
+	for i, item := range newTodoItemModels(todoList, nil, false) {
+		state.TodoList = append(state.TodoList, todoDataSourceItemModel{
+			ID:          types.StringValue(todoList[i].ID),
+			Title:       item.Title,
+			Description: item.Description,
+			Done:        item.Done,
+			Priority:    item.Priority,
+			DueAt:       item.DueAt,
+			Tags:        item.Tags,
+		})
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -98,15 +217,15 @@ func (d *GetToDoDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	baseurl, ok := req.ProviderData.(string)
+	client, ok := req.ProviderData.(*todoclient.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected string as baseurl, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *todoclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.baseurl = baseurl
+	d.client = client
 }
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"terraform-provider-custom-example/internal/todoserver"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTodoDataSource(t *testing.T) {
+	srv := newTestServer(t)
+	dueAt := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	srv.SetItems(
+		todoserver.Item{Title: "A", Description: "first", Done: true, Priority: 2, DueAt: &dueAt, Tags: []string{"x"}},
+		todoserver.Item{Title: "B"},
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "customexample_todo" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.#", "2"),
+					resource.TestCheckResourceAttrSet("data.customexample_todo.test", "todo_list.0.id"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.title", "A"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.description", "first"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.done", "true"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.priority", "2"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.due_at", "2024-05-01T09:30:00Z"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.tags.#", "1"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.tags.0", "x"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.1.title", "B"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.1.done", "false"),
+					resource.TestCheckNoResourceAttr("data.customexample_todo.test", "todo_list.1.due_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTodoDataSource_query(t *testing.T) {
+	for name, opts := range map[string]todoserver.Options{
+		"server side": {},
+		"client side": {DisableQuery: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := newTestServerWithOptions(t, opts)
+			srv.SetItems(
+				todoserver.Item{Title: "chore: dishes", Priority: 2, Tags: []string{"home"}},
+				todoserver.Item{Title: "work: report", Priority: 3},
+				todoserver.Item{Title: "chore: laundry", Priority: 1, Tags: []string{"home"}},
+				todoserver.Item{Title: "chore: bins", Priority: 0, Tags: []string{"home"}, Done: true},
+				todoserver.Item{Title: "chore: garden", Priority: 5, Tags: []string{"home"}},
+			)
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + `
+data "customexample_todo" "test" {
+  title_prefix = "chore:"
+  tag          = "home"
+  done         = false
+  sort_by      = "priority"
+  offset       = 1
+  limit        = 1
+}
+
+data "customexample_todo" "regex" {
+  title_regex = "^work:"
+}
+`,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.#", "1"),
+							resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.0.title", "chore: dishes"),
+							resource.TestCheckResourceAttr("data.customexample_todo.regex", "todo_list.#", "1"),
+							resource.TestCheckResourceAttr("data.customexample_todo.regex", "todo_list.0.title", "work: report"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccTodoDataSource_invalidQuery(t *testing.T) {
+	newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + `data "customexample_todo" "test" { title_regex = "(" }`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			{
+				Config:      providerConfig + `data "customexample_todo" "test" { sort_by = "size" }`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestAccTodoDataSource_linkPagination(t *testing.T) {
+	srv := newTestServerWithOptions(t, todoserver.Options{LinkPagination: true})
+	srv.SetTitles("A1", "B1", "A2", "A3", "B2", "A4")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "customexample" {
+  max_retries = 0
+  page_size   = 1
+}
+
+data "customexample_todo" "test" {
+  title_prefix = "A"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.#", "4"),
+					resource.TestCheckResourceAttr("data.customexample_todo.test", "todo_list.3.title", "A4"),
+				),
+			},
+		},
+	})
+}
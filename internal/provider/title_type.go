@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = titleType{}
+	_ basetypes.StringValuableWithSemanticEquals = titleValue{}
+)
+
+// titleType is the type of todo titles. Its values are semantically equal
+// when they only differ by leading or trailing whitespace or by Unicode
+// normalization, so a server normalizing titles does not make Terraform
+// report an inconsistent result.
+type titleType struct {
+	basetypes.StringType
+}
+
+func (t titleType) Equal(o attr.Type) bool {
+	other, ok := o.(titleType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t titleType) String() string {
+	return "titleType"
+}
+
+func (t titleType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return titleValue{StringValue: in}, nil
+}
+
+func (t titleType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t titleType) ValueType(_ context.Context) attr.Value {
+	return titleValue{}
+}
+
+// titleValue is a todo title.
+type titleValue struct {
+	basetypes.StringValue
+}
+
+// newTitleValue returns a known title.
+func newTitleValue(title string) titleValue {
+	return titleValue{StringValue: basetypes.NewStringValue(title)}
+}
+
+func (v titleValue) Equal(o attr.Value) bool {
+	other, ok := o.(titleValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v titleValue) Type(_ context.Context) attr.Type {
+	return titleType{}
+}
+
+// StringSemanticEquals reports whether both titles are the same once
+// normalized.
+func (v titleValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(titleValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	return titlesEqual(v.ValueString(), newValue.ValueString(), false), diags
+}
+
+// normalizeTitle trims whitespace and applies Unicode NFC normalization,
+// the way servers commonly store titles.
+func normalizeTitle(title string) string {
+	return norm.NFC.String(strings.TrimSpace(title))
+}
+
+// titlesEqual reports whether a and b are the same title once normalized,
+// ignoring case when ignoreCase is set.
+func titlesEqual(a, b string, ignoreCase bool) bool {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if ignoreCase {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
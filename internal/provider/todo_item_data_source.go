@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-custom-example/internal/todoclient"
+	"terraform-provider-custom-example/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &todoItemDataSource{}
+	_ datasource.DataSourceWithConfigure        = &todoItemDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &todoItemDataSource{}
+)
+
+// NewTodoItemDataSource is a helper function to simplify the provider implementation.
+func NewTodoItemDataSource() datasource.DataSource {
+	return &todoItemDataSource{}
+}
+
+// todoItemDataSource is the data source implementation.
+type todoItemDataSource struct {
+	client *todoclient.Client
+}
+
+// todoItemDataSourceModel maps the data source schema data.
+type todoItemDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Index       types.Int64  `tfsdk:"index"`
+	Title       titleValue   `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Done        types.Bool   `tfsdk:"done"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	DueAt       types.String `tfsdk:"due_at"`
+	Tags        []string     `tfsdk:"tags"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoItemDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_item"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoItemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a single item of the todo list, looked up by exactly one of id, index or title.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the item, assigned by the server.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"index": schema.Int64Attribute{
+				Description: "Zero-based position of the item in the todo list.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "Text of the item, ignoring leading and trailing whitespace and Unicode normalization. " +
+					"Exactly one item must have this title.",
+				CustomType: titleType{},
+				Optional:   true,
+				Computed:   true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Longer description of the todo item.",
+				Computed:    true,
+			},
+			"done": schema.BoolAttribute{
+				Description: "Whether the todo item is completed.",
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the todo item.",
+				Computed:    true,
+			},
+			"due_at": schema.StringAttribute{
+				Description: "Due date of the todo item as an RFC 3339 timestamp, if any.",
+				Computed:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Tags of the todo item.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one way of looking up the item.
+func (d *todoItemDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("index"),
+			path.MatchRoot("title"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoItemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.customexample_todo_item.Read")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
+
+	var state todoItemDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The whole list is read so the position of the item is known however
+	// it is looked up
+	todoList, err := d.client.List(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to Read Todo List", err)
+		return
+	}
+
+	index := -1
+	switch {
+	case !state.ID.IsNull():
+		for i, item := range todoList {
+			if item.ID == state.ID.ValueString() {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Todo Item Not Found",
+				fmt.Sprintf("No item of the todo list has the ID %q.", state.ID.ValueString()),
+			)
+			return
+		}
+	case !state.Index.IsNull():
+		index = int(state.Index.ValueInt64())
+		if index >= len(todoList) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("index"),
+				"Todo Item Not Found",
+				fmt.Sprintf("The todo list has %d item(s), so there is no item at index %d.", len(todoList), index),
+			)
+			return
+		}
+	default:
+		var matches []int
+		for i, item := range todoList {
+			if titlesEqual(item.Title, state.Title.ValueString(), false) {
+				matches = append(matches, i)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("title"),
+				"Todo Item Not Found",
+				fmt.Sprintf("No item of the todo list has the title %q.", state.Title.ValueString()),
+			)
+			return
+		case 1:
+			index = matches[0]
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("title"),
+				"Multiple Todo Items Found",
+				fmt.Sprintf("%d items of the todo list, at indexes %v, have the title %q. "+
+					"Look the item up by id or index instead.", len(matches), matches, state.Title.ValueString()),
+			)
+			return
+		}
+	}
+
+	item := todoList[index]
+	tflog.Debug(ctx, "Read todo item", map[string]any{"id": item.ID, "index": index})
+
+	// Map response body to model
+	m := newTodoItemModels([]todoclient.Item{item}, nil, false)[0]
+	state.ID = types.StringValue(item.ID)
+	state.Index = types.Int64Value(int64(index))
+	state.Title = m.Title
+	state.Description = m.Description
+	state.Done = m.Done
+	state.Priority = m.Priority
+	state.DueAt = m.DueAt
+	state.Tags = m.Tags
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoItemDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*todoclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *todoclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
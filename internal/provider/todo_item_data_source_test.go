@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"terraform-provider-custom-example/internal/todoserver"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTodoItemDataSource(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetItems(
+		todoserver.Item{Title: "A"},
+		todoserver.Item{Title: "B", Priority: 2, Tags: []string{"x"}},
+		todoserver.Item{Title: "C"},
+	)
+	id := srv.Items()[1].ID
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "customexample_todo_item" "by_id" {
+  id = "` + id + `"
+}
+
+data "customexample_todo_item" "by_index" {
+  index = 1
+}
+
+data "customexample_todo_item" "by_title" {
+  title = " B "
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_id", "index", "1"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_id", "title", "B"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_id", "priority", "2"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_id", "tags.0", "x"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_index", "id", id),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_index", "title", "B"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_title", "id", id),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_title", "index", "1"),
+					resource.TestCheckResourceAttr("data.customexample_todo_item.by_title", "title", " B "),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTodoItemDataSource_noSingleMatch(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetTitles("A", "B", "A")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + `data "customexample_todo_item" "test" { title = "A" }`,
+				ExpectError: regexp.MustCompile(`Multiple Todo Items Found`),
+			},
+			{
+				Config:      providerConfig + `data "customexample_todo_item" "test" { title = "Z" }`,
+				ExpectError: regexp.MustCompile(`Todo Item Not Found`),
+			},
+			{
+				Config:      providerConfig + `data "customexample_todo_item" "test" { index = 3 }`,
+				ExpectError: regexp.MustCompile(`Todo Item Not Found`),
+			},
+			{
+				Config:      providerConfig + `data "customexample_todo_item" "test" { id = "missing" }`,
+				ExpectError: regexp.MustCompile(`Todo Item Not Found`),
+			},
+			{
+				Config:      providerConfig + `data "customexample_todo_item" "test" {}`,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			{
+				Config: providerConfig + `
+data "customexample_todo_item" "test" {
+  index = 0
+  title = "B"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-custom-example/internal/todoclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// todoItemModel maps a todo object of the todo_list attribute.
+type todoItemModel struct {
+	Title       titleValue   `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Done        types.Bool   `tfsdk:"done"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	DueAt       types.String `tfsdk:"due_at"`
+	Tags        []string     `tfsdk:"tags"`
+}
+
+// equal reports whether m and o describe the same todo, comparing titles
+// once normalized.
+func (m todoItemModel) equal(o todoItemModel) bool {
+	return titlesEqual(m.Title.ValueString(), o.Title.ValueString(), false) && m.Description.Equal(o.Description) &&
+		m.Done.Equal(o.Done) && m.Priority.Equal(o.Priority) && m.DueAt.Equal(o.DueAt) && slices.Equal(m.Tags, o.Tags)
+}
+
+// toAPI converts the item to its API payload, reporting an invalid due_at
+// against itemPath.
+func (m todoItemModel) toAPI(itemPath path.Path) (todoclient.Item, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	item := todoclient.Item{
+		Title:       m.Title.ValueString(),
+		Description: m.Description.ValueString(),
+		Done:        m.Done.ValueBool(),
+		Priority:    m.Priority.ValueInt64(),
+		Tags:        m.Tags,
+	}
+	if !m.DueAt.IsNull() {
+		dueAt, err := time.Parse(time.RFC3339, m.DueAt.ValueString())
+		if err != nil {
+			diags.AddAttributeError(itemPath.AtName("due_at"), "Invalid Due Date", err.Error())
+			return item, diags
+		}
+		item.DueAt = &dueAt
+	}
+	return item, diags
+}
+
+// withNullZeroValues returns m with the attributes that hold their zero
+// value set to null, unless prior sets them. It maps items read from the
+// API to attributes without defaults, where an unset attribute is null.
+func (m todoItemModel) withNullZeroValues(prior todoItemModel) todoItemModel {
+	if prior.Description.IsNull() && m.Description.ValueString() == "" {
+		m.Description = types.StringNull()
+	}
+	if prior.Done.IsNull() && !m.Done.ValueBool() {
+		m.Done = types.BoolNull()
+	}
+	if prior.Priority.IsNull() && m.Priority.ValueInt64() == 0 {
+		m.Priority = types.Int64Null()
+	}
+	if prior.Tags == nil && len(m.Tags) == 0 {
+		m.Tags = nil
+	}
+	return m
+}
+
+// key identifies the todo described by m, for comparing items regardless
+// of their order.
+func (m todoItemModel) key() string {
+	return strings.Join([]string{
+		normalizeTitle(m.Title.ValueString()),
+		m.Description.String(),
+		m.Done.String(),
+		m.Priority.String(),
+		m.DueAt.String(),
+		strings.Join(m.Tags, "\x00"),
+	}, "\x01")
+}
+
+// itemPathFunc returns the path of the item sent at the given position of
+// an API payload, so diagnostics point at the offending element.
+type itemPathFunc func(index int) path.Path
+
+// listItemPaths returns the paths of the elements of the list at listPath.
+func listItemPaths(listPath path.Path) itemPathFunc {
+	return func(index int) path.Path {
+		return listPath.AtListIndex(index)
+	}
+}
+
+// setItemPaths returns the paths of the elements of set, the value of the
+// set at setPath, in the order they are decoded.
+func setItemPaths(setPath path.Path, set types.Set) itemPathFunc {
+	elements := set.Elements()
+	return func(index int) path.Path {
+		if index < 0 || index >= len(elements) {
+			return setPath
+		}
+		return setPath.AtSetValue(elements[index])
+	}
+}
+
+// todoItemsToAPI converts todo items to their API payloads.
+func todoItemsToAPI(itemPath itemPathFunc, models []todoItemModel) ([]todoclient.Item, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	items := make([]todoclient.Item, 0, len(models))
+	for i, m := range models {
+		item, itemDiags := m.toAPI(itemPath(i))
+		diags.Append(itemDiags...)
+		items = append(items, item)
+	}
+	return items, diags
+}
+
+// newTodoItemModels maps API items to todo item models. The title and due
+// date are kept as written in prior, the item at the same position in the
+// configuration or state, when they are equivalent to the returned ones,
+// so a server normalizing titles or time zones does not cause a diff.
+// Titles differing by case are equivalent when ignoreCase is set.
+func newTodoItemModels(items []todoclient.Item, prior []todoItemModel, ignoreCase bool) []todoItemModel {
+	models := make([]todoItemModel, 0, len(items))
+	for i, item := range items {
+		m := todoItemModel{
+			Title:       newTitleValue(item.Title),
+			Description: types.StringValue(item.Description),
+			Done:        types.BoolValue(item.Done),
+			Priority:    types.Int64Value(item.Priority),
+			DueAt:       types.StringNull(),
+			Tags:        item.Tags,
+		}
+		// Tags default to an empty list rather than null
+		if m.Tags == nil {
+			m.Tags = []string{}
+		}
+		if i < len(prior) && !prior[i].Title.IsNull() && !prior[i].Title.IsUnknown() &&
+			titlesEqual(prior[i].Title.ValueString(), item.Title, ignoreCase) {
+			m.Title = prior[i].Title
+		}
+		if item.DueAt != nil {
+			m.DueAt = types.StringValue(item.DueAt.Format(time.RFC3339))
+			if i < len(prior) && sameInstant(prior[i].DueAt, *item.DueAt) {
+				m.DueAt = prior[i].DueAt
+			}
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// matchPrior returns the items of prior paired with items by title, in
+// the order of items, for passing to newTodoItemModels when the order of
+// the items is not meaningful.
+func matchPrior(items []todoclient.Item, prior []todoItemModel, ignoreCase bool) []todoItemModel {
+	used := make([]bool, len(prior))
+	matched := make([]todoItemModel, len(items))
+	for i, item := range items {
+		for j, p := range prior {
+			if !used[j] && titlesEqual(p.Title.ValueString(), item.Title, ignoreCase) {
+				used[j] = true
+				matched[i] = p
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// uniqueTodoItems drops repeated items, which a set cannot hold.
+func uniqueTodoItems(models []todoItemModel) []todoItemModel {
+	seen := make(map[string]bool, len(models))
+	unique := make([]todoItemModel, 0, len(models))
+	for _, m := range models {
+		if key := m.key(); !seen[key] {
+			seen[key] = true
+			unique = append(unique, m)
+		}
+	}
+	return unique
+}
+
+// sameTodoItems reports whether a and b hold the same items, in the same
+// order when ordered is true.
+func sameTodoItems(a, b []todoItemModel, ordered bool) bool {
+	if ordered {
+		return slices.EqualFunc(a, b, todoItemModel.equal)
+	}
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, m := range a {
+		counts[m.key()]++
+	}
+	for _, m := range b {
+		key := m.key()
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
+
+func sameInstant(value types.String, t time.Time) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+	parsed, err := time.Parse(time.RFC3339, value.ValueString())
+	return err == nil && parsed.Equal(t)
+}
+
+// isFullyKnown reports whether value and every value nested in it are
+// known, which is required before it can be sent to the API.
+func isFullyKnown(value attr.Value) bool {
+	if value.IsUnknown() {
+		return false
+	}
+
+	switch value := value.(type) {
+	case types.Object:
+		for _, v := range value.Attributes() {
+			if !isFullyKnown(v) {
+				return false
+			}
+		}
+	case types.List:
+		for _, v := range value.Elements() {
+			if !isFullyKnown(v) {
+				return false
+			}
+		}
+	case types.Set:
+		for _, v := range value.Elements() {
+			if !isFullyKnown(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// todoItemCollection is the value of todo_list or todo_set.
+type todoItemCollection interface {
+	attr.Value
+	ElementsAs(ctx context.Context, target any, allowUnhandled bool) diag.Diagnostics
+}
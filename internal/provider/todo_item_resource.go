@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-custom-example/internal/todoclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &todoItemResource{}
+	_ resource.ResourceWithConfigure   = &todoItemResource{}
+	_ resource.ResourceWithImportState = &todoItemResource{}
+)
+
+// NewTodoItemResource is a helper function to simplify the provider implementation.
+func NewTodoItemResource() resource.Resource {
+	return &todoItemResource{}
+}
+
+// todoItemResource is the resource implementation.
+type todoItemResource struct {
+	client *todoclient.Client
+}
+
+// todoItemResourceModel maps the resource schema data.
+type todoItemResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Title    titleValue     `tfsdk:"title"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *todoItemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_item"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoItemResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single item of the todo list. Only the title is managed; renaming the item keeps its other attributes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the item, assigned by the server.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "Text of the todo item.",
+				CustomType:  titleType{},
+				Required:    true,
+				Validators:  titleValidators(),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create a new resource.
+func (r *todoItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan todoItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Create the item
+	item, err := r.client.CreateItem(ctx, todoclient.Item{
+		Title: plan.Title.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to Create Todo Item", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Created todo item", map[string]any{"id": item.ID})
+
+	// Map response body to model
+	plan.ID = types.StringValue(item.ID)
+	plan.Title = newTitleValue(item.Title)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *todoItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state todoItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed item from the API
+	item, err := r.client.GetItem(ctx, state.ID.ValueString())
+	if todoclient.HasStatus(err, http.StatusNotFound) {
+		tflog.Warn(ctx, "Todo item not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to Read Todo Item", err)
+		return
+	}
+
+	// Overwrite state with refreshed values
+	state.ID = types.StringValue(item.ID)
+	state.Title = newTitleValue(item.Title)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource information.
+func (r *todoItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan todoItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// The API replaces the whole item, so start from the current one to
+	// keep the attributes the resource does not manage
+	item, err := r.client.GetItem(ctx, plan.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to Update Todo Item", err)
+		return
+	}
+	item.Title = plan.Title.ValueString()
+
+	// Update the existing item
+	item, err = r.client.UpdateItem(ctx, plan.ID.ValueString(), *item)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to Update Todo Item", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Updated todo item", map[string]any{"id": item.ID})
+
+	// Map response body to model
+	plan.ID = types.StringValue(item.ID)
+	plan.Title = newTitleValue(item.Title)
+
+	// Set state to the values returned by the API
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource information.
+func (r *todoItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state todoItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the item, treating an already deleted item as success
+	err := r.client.DeleteItem(ctx, state.ID.ValueString())
+	if err != nil && !todoclient.HasStatus(err, http.StatusNotFound) {
+		addClientError(&resp.Diagnostics, "Unable to Delete Todo Item", err)
+		return
+	}
+	tflog.Debug(ctx, "Deleted todo item", map[string]any{"id": state.ID.ValueString()})
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoItemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *todoItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTodoItemResource(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetTitles("foreign")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv, "foreign"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "customexample_todo_item" "test" {
+  title = "A"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("customexample_todo_item.test", "id"),
+					resource.TestCheckResourceAttr("customexample_todo_item.test", "title", "A"),
+					testAccCheckTodoList(srv, "foreign", "A"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "customexample_todo_item.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, keeping the attributes set by
+			// another client
+			{
+				PreConfig: func() {
+					item := srv.Items()[1]
+					item.Description = "Set elsewhere"
+					item.Done = true
+					item.Tags = []string{"home"}
+					srv.UpdateItem(item)
+				},
+				Config: providerConfig + `
+resource "customexample_todo_item" "test" {
+  title = "B"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_todo_item.test", "title", "B"),
+					testAccCheckTodoList(srv, "foreign", "B"),
+					func(_ *terraform.State) error {
+						item := srv.Items()[1]
+						if item.Description != "Set elsewhere" || !item.Done || !slices.Equal(item.Tags, []string{"home"}) {
+							return fmt.Errorf("expected renaming to keep the other attributes, got %+v", item)
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -1,23 +1,66 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-
+	"regexp"
+	"slices"
+	"time"
+
+	"terraform-provider-custom-example/internal/todoclient"
+	"terraform-provider-custom-example/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Limits on the managed items, checked when validating the configuration.
+const (
+	maxTodoItems         = 1000
+	maxTitleLength       = 256
+	maxDescriptionLength = 4096
 )
 
+// What destroying customexample_add_todo_items does to the todo list.
+const (
+	deleteClearAll           = "clear_all"
+	deleteRemoveManagedItems = "remove_managed_items"
+	deleteArchive            = "archive"
+	deleteAbandon            = "abandon"
+)
+
+// defaultOperationTimeout bounds a whole create, read, update or delete,
+// including retries, when no timeouts block is configured.
+const defaultOperationTimeout = 10 * time.Minute
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &addTodoResource{}
 	_ resource.ResourceWithConfigure   = &addTodoResource{}
 	_ resource.ResourceWithImportState = &addTodoResource{}
+	_ resource.ResourceWithModifyPlan  = &addTodoResource{}
+
+	_ resource.ResourceWithConfigValidators = &addTodoResource{}
+	_ resource.ResourceWithUpgradeState     = &addTodoResource{}
 )
 
 // NewAddTodoResource is a helper function to simplify the provider implementation.
@@ -27,12 +70,85 @@ func NewAddTodoResource() resource.Resource {
 
 // addTodoResource is the resource implementation.
 type addTodoResource struct {
-	baseurl string
+	client       *todoclient.Client
+	titlePattern *regexp.Regexp
 }
 
 // orderResourceModel maps the resource schema data.
 type orderResourceModel struct {
-	TodoList []string `tfsdk:"todo_list"`
+	ID              types.String    `tfsdk:"id"`
+	TodoList        []todoItemModel `tfsdk:"todo_list"`
+	TodoSet         []todoItemModel `tfsdk:"todo_set"`
+	IgnoreTitleCase types.Bool      `tfsdk:"ignore_title_case"`
+	Mode            types.String    `tfsdk:"mode"`
+	ManagedItemIDs  types.List      `tfsdk:"managed_item_ids"`
+	DeleteBehavior  types.String    `tfsdk:"delete_behavior"`
+	Timeouts        timeouts.Value  `tfsdk:"timeouts"`
+}
+
+// additive reports whether the resource only manages the items it created.
+func (m orderResourceModel) additive() bool {
+	return m.Mode.ValueString() == modeAdditive
+}
+
+// managedItemIDs returns the IDs of the items recorded in state.
+func (m orderResourceModel) managedItemIDs(ctx context.Context) ([]string, diag.Diagnostics) {
+	var ids []string
+	if m.ManagedItemIDs.IsNull() || m.ManagedItemIDs.IsUnknown() {
+		return ids, nil
+	}
+	diags := m.ManagedItemIDs.ElementsAs(ctx, &ids, false)
+	return ids, diags
+}
+
+// unordered reports whether the items are managed through todo_set.
+func (m orderResourceModel) unordered() bool {
+	return m.TodoSet != nil
+}
+
+// todoItems returns the managed items, from todo_set or todo_list.
+func (m orderResourceModel) todoItems() []todoItemModel {
+	if m.unordered() {
+		return m.TodoSet
+	}
+	return m.TodoList
+}
+
+// setTodoItems stores the items returned by the API in the attribute in
+// use, keeping titles and due dates as written in prior, and records their
+// IDs. A todo_set drops repeated items but the IDs of every item are kept,
+// so destroying the resource also removes the repeats; managed_item_ids can
+// then hold more elements than todo_set.
+func (m *orderResourceModel) setTodoItems(items []todoclient.Item, prior []todoItemModel) {
+	ids := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, types.StringValue(item.ID))
+	}
+	m.ManagedItemIDs = types.ListValueMust(types.StringType, ids)
+
+	ignoreCase := m.IgnoreTitleCase.ValueBool()
+	if m.unordered() {
+		prior = matchPrior(items, prior, ignoreCase)
+		models := newTodoItemModels(items, prior, ignoreCase)
+		for i := range models {
+			models[i] = models[i].withNullZeroValues(prior[i])
+		}
+		m.TodoSet = uniqueTodoItems(models)
+		return
+	}
+	m.TodoList = newTodoItemModels(items, prior, ignoreCase)
+}
+
+// todoItemPaths returns the paths of the planned items, for reporting
+// errors against them.
+func todoItemPaths(ctx context.Context, plan tfsdk.Plan, unordered bool) (itemPathFunc, diag.Diagnostics) {
+	if !unordered {
+		return listItemPaths(path.Root("todo_list")), nil
+	}
+
+	var todoSet types.Set
+	diags := plan.GetAttribute(ctx, path.Root("todo_set"), &todoSet)
+	return setItemPaths(path.Root("todo_set"), todoSet), diags
 }
 
 // Metadata returns the resource type name.
@@ -41,20 +157,103 @@ func (r *addTodoResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *addTodoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *addTodoResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Adds items to the todo list.",
+		// Version 1 turned the titles of todo_list into objects
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"todo_list": schema.ListAttribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the managed todo list, the base URL of the API serving it. " +
+					"Any value can be used when importing; the list is always read from the configured provider.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"todo_list": schema.ListNestedAttribute{
+				Description: "Items of the todo list, in order. Exactly one of todo_list and todo_set must be set; " +
+					"importing always populates todo_list.",
+				Optional:     true,
+				NestedObject: todoItemNestedObject(true),
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, maxTodoItems),
+					uniqueTitlesValidator{},
+				},
+			},
+			"todo_set": schema.SetNestedAttribute{
+				Description: "Items of the todo list, in any order. Use it instead of todo_list when the server " +
+					"does not preserve the order of the items, or the order does not matter. " +
+					"Unlike in todo_list, unset attributes of the items stay null instead of taking a default value.",
+				Optional:     true,
+				NestedObject: todoItemNestedObject(false),
+				Validators: []validator.Set{
+					setvalidator.SizeBetween(1, maxTodoItems),
+					uniqueTitlesValidator{},
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: fmt.Sprintf("Ownership of the todo list, %q or %q. An %q resource manages the whole list: "+
+					"updates replace it and every item read from it is managed. An %q resource only manages the items it "+
+					"created, tracked in managed_item_ids, and ignores the others, so several configurations can share "+
+					"one list. See delete_behavior for what destroying the resource removes. Changing it replaces the resource. Defaults to %q.",
+					modeAuthoritative, modeAdditive, modeAuthoritative, modeAdditive, modeAuthoritative),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(modeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(modeAuthoritative, modeAdditive),
+				},
+				PlanModifiers: []planmodifier.String{
+					// States written before the attribute existed hold null
+					stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull()
+					}, "Changing the ownership mode replaces the resource.", "Changing the ownership mode replaces the resource."),
+				},
+			},
+			"delete_behavior": schema.StringAttribute{
+				Description: fmt.Sprintf("What destroying the resource does to the todo list: %q empties the whole list, "+
+					"%q deletes the items in managed_item_ids, %q moves them to the archive of the server, and %q only "+
+					"removes the resource from state, leaving every item in place. %q and %q need a server returning item IDs. "+
+					"Defaults to %q.",
+					deleteClearAll, deleteRemoveManagedItems, deleteArchive, deleteAbandon, deleteRemoveManagedItems, deleteArchive,
+					deleteRemoveManagedItems),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(deleteRemoveManagedItems),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteClearAll, deleteRemoveManagedItems, deleteArchive, deleteAbandon),
+				},
+			},
+			"managed_item_ids": schema.ListAttribute{
+				Description: "IDs of the items managed by the resource, in the order of the items: every item of the list " +
+					"in authoritative mode, the items the resource created in additive mode. Repeated items, which todo_set " +
+					"holds only once, keep their own IDs, so the list can be longer than todo_set.",
 				ElementType: types.StringType,
-				Required:    true,
+				Computed:    true,
+			},
+			"ignore_title_case": schema.BoolAttribute{
+				Description: "Whether titles differing only by case are the same, for servers that change the case " +
+					"of titles. Leading and trailing whitespace and Unicode normalization are always ignored.",
+				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
 // Create a new resource.
 func (r *addTodoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "customexample_add_todo_items.Create")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
+
 	// Retrieve values from plan
 	var plan orderResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -63,56 +262,54 @@ func (r *addTodoResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// Generate API request body from plan
-	rb, err := json.Marshal(plan.TodoList)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to marshal the items",
-			err.Error(),
-		)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	addRequest, err := http.NewRequest("POST", fmt.Sprintf("%s/create", r.baseurl), bytes.NewBuffer(rb))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create new request for add endpoint",
-			err.Error(),
-		)
-		return
-	}
-	addRequest.Header.Add("Content-Type", "application/json")
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	client := &http.Client{}
-	res, err := client.Do(addRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to hit /create endpoint",
-			err.Error(),
-		)
+	itemPath, diags := todoItemPaths(ctx, req.Plan, plan.unordered())
+	resp.Diagnostics.Append(diags...)
+	items, diags := todoItemsToAPI(itemPath, plan.todoItems())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError(
-			"Received non-OK response from /create endpoint",
-			fmt.Sprintf("Status code: %d", res.StatusCode),
-		)
-		return
+	// Add the planned items to the todo list
+	var (
+		todoList []todoclient.Item
+		err      error
+	)
+	if plan.additive() {
+		todoList, err = r.applyAdditive(ctx, items, nil, plan.IgnoreTitleCase.ValueBool())
+	} else {
+		todoList, err = r.client.Create(ctx, items)
 	}
-
-	// new orderResourceModel state is used to store the response data
-	state := orderResourceModel{}
-	err = json.NewDecoder(res.Body).Decode(&state.TodoList)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read the response body",
-			err.Error(),
-		)
+		if !addItemErrors(&resp.Diagnostics, itemPath, err) {
+			addClientError(&resp.Diagnostics, "Unable to Create Todo Items", err)
+		}
+		if len(todoList) > 0 {
+			r.savePartialState(ctx, plan, todoList, &resp.State, &resp.Diagnostics)
+		}
 		return
 	}
-	// Set state to the values sent in the request
+
+	tflog.Debug(ctx, "Created todo items", map[string]any{
+		"planned_items": len(items),
+		"list_items":    len(todoList),
+	})
+
+	// Map response body to model
+	state := plan
+	state.ID = types.StringValue(r.client.BaseURL())
+	state.setTodoItems(todoList, plan.todoItems())
+
+	// Set state to the values returned by the API
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -122,36 +319,82 @@ func (r *addTodoResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read resource information.
 func (r *addTodoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "customexample_add_todo_items.Read")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
+
+	// Get current state, which only holds the id right after an import
 	var state orderResourceModel
-	// diags := req.State.Get(ctx, &state)
-	// resp.Diagnostics.Append(diags...)
-	// if resp.Diagnostics.HasError() {
-	// 	return
-	// }
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	res, err := http.Get(fmt.Sprintf("%s/get", r.baseurl))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to hit the Todo List get endpoint",
-			err.Error(),
-		)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer res.Body.Close()
 
-	var responseItems []string
-	err = json.NewDecoder(res.Body).Decode(&responseItems)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// States written before id, mode and delete_behavior existed get
+	// their defaults
+	if state.ID.IsNull() {
+		state.ID = types.StringValue(r.client.BaseURL())
+	}
+	if state.Mode.IsNull() {
+		state.Mode = types.StringValue(modeAuthoritative)
+	}
+	if state.DeleteBehavior.IsNull() {
+		state.DeleteBehavior = types.StringValue(deleteRemoveManagedItems)
+	}
+
+	var (
+		todoList []todoclient.Item
+		err      error
+	)
+	if state.additive() {
+		ids, diags := state.managedItemIDs(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		todoList, err = r.listManagedItems(ctx, ids)
+	} else {
+		todoList, err = r.client.List(ctx)
+	}
+	if todoclient.HasStatus(err, http.StatusNotFound) {
+		tflog.Warn(ctx, "Todo list not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read the response body",
-			err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Unable to Read Todo List", err)
+		return
+	}
+
+	// The list, or every managed item, was deleted outside of Terraform, so
+	// plan to create it again
+	if len(todoList) == 0 {
+		tflog.Warn(ctx, "Todo list is empty, removing it from state")
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Set state
-	state.TodoList = responseItems
-	diags := resp.State.Set(ctx, &state)
+	// Overwrite the prior items with the remote ones so out-of-band edits
+	// show up as a diff in the plan. A todo_list also reports a change of
+	// order, a todo_set does not.
+	prior := state.todoItems()
+	state.setTodoItems(todoList, prior)
+	if prior != nil && !sameTodoItems(prior, state.todoItems(), !state.unordered()) {
+		tflog.Info(ctx, "Todo list changed outside of Terraform", map[string]any{
+			"prior_items":  len(prior),
+			"remote_items": len(todoList),
+		})
+	}
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -160,6 +403,9 @@ func (r *addTodoResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update resource information.
 func (r *addTodoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "customexample_add_todo_items.Update")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
+
 	// Retrieve values from plan
 	var plan orderResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -168,109 +414,242 @@ func (r *addTodoResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Generate API request body from plan
-	rb, err := json.Marshal(plan.TodoList)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to marshal the items",
-			err.Error(),
-		)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updateRequest, err := http.NewRequest("PUT", fmt.Sprintf("%s/update", r.baseurl), bytes.NewBuffer(rb))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create new request for add endpoint to update the todo list",
-			err.Error(),
-		)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	itemPath, diags := todoItemPaths(ctx, req.Plan, plan.unordered())
+	resp.Diagnostics.Append(diags...)
+	items, diags := todoItemsToAPI(itemPath, plan.todoItems())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	updateRequest.Header.Add("Content-Type", "application/json")
 
-	client := &http.Client{}
-	res, err := client.Do(updateRequest)
+	// Replace the todo list, or the managed items, with the planned items
+	var (
+		todoList []todoclient.Item
+		err      error
+	)
+	if plan.additive() {
+		var state orderResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		ids, diags := state.managedItemIDs(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		todoList, err = r.applyAdditive(ctx, items, ids, plan.IgnoreTitleCase.ValueBool())
+	} else {
+		todoList, err = r.client.Replace(ctx, items)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to hit /update endpoint to update todo list",
-			err.Error(),
-		)
+		if !addItemErrors(&resp.Diagnostics, itemPath, err) {
+			addClientError(&resp.Diagnostics, "Unable to Update Todo List", err)
+		}
+		r.savePartialState(ctx, plan, todoList, &resp.State, &resp.Diagnostics)
 		return
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError(
-			"Received non-OK response from /update endpoint",
-			fmt.Sprintf("Status code: %d", res.StatusCode),
-		)
+	tflog.Debug(ctx, "Replaced todo list", map[string]any{
+		"list_items": len(todoList),
+	})
+
+	// Map response body to model. The planned id is unknown when the prior
+	// state had none.
+	state := plan
+	state.ID = types.StringValue(r.client.BaseURL())
+	state.setTodoItems(todoList, plan.todoItems())
+
+	// Set state to the values returned by the API
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	state := orderResourceModel{}
-	err = json.NewDecoder(res.Body).Decode(&state.TodoList)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read the response body",
-			fmt.Sprint(state.TodoList, "\n", err.Error()),
-		)
+// Delete resource information.
+func (r *addTodoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "customexample_add_todo_items.Delete")
+	defer func() { tracing.End(span, resp.Diagnostics) }()
+
+	// Retrieve values from state
+	var state orderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Set state to the values sent in the request
-	diags = resp.State.Set(ctx, &state)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ids, diags := state.managedItemIDs(ctx)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	switch behavior := state.DeleteBehavior.ValueString(); behavior {
+	case deleteClearAll:
+		err := r.client.DeleteAll(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to Delete Todo List", err)
+			return
+		}
+		tflog.Debug(ctx, "Deleted todo list")
+	case deleteAbandon:
+		tflog.Warn(ctx, "Abandoning todo items, leaving them on the server", map[string]any{"items": len(ids)})
+	default:
+		// States written before managed_item_ids existed manage the whole list
+		if state.ManagedItemIDs.IsNull() && !state.additive() {
+			todoList, err := r.client.List(ctx)
+			if err != nil {
+				addClientError(&resp.Diagnostics, "Unable to Read Todo List", err)
+				return
+			}
+			for _, item := range todoList {
+				ids = append(ids, item.ID)
+			}
+		}
+
+		remove, summary := r.client.DeleteItem, "Unable to Delete Todo Items"
+		if behavior == deleteArchive {
+			remove, summary = r.client.ArchiveItem, "Unable to Archive Todo Items"
+		}
+
+		// Servers predating item IDs return bare titles; an empty ID would
+		// only get a 404, taken for an item already gone
+		if slices.Contains(ids, "") {
+			resp.Diagnostics.AddError(summary, fmt.Sprintf("The todo API did not return the IDs of the managed items, "+
+				"so they cannot be removed one by one. Set delete_behavior to %q to empty the whole list, "+
+				"or to %q to leave the items in place, then destroy the resource again.", deleteClearAll, deleteAbandon))
+			return
+		}
+		err := r.removeItems(ctx, ids, remove)
+		if err != nil {
+			addClientError(&resp.Diagnostics, summary, err)
+			return
+		}
+		tflog.Debug(ctx, "Removed managed todo items", map[string]any{"items": len(ids), "delete_behavior": behavior})
+	}
+
+	resp.State.RemoveResource(ctx)
 }
 
-// Delete resource information.
-func (r *addTodoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// making delete request
-	deleteRequest, err := http.NewRequest("DELETE", fmt.Sprintf("%s/delete", r.baseurl), nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create new request for delete endpoint to delete the todo list",
-			err.Error(),
-		)
+// savePartialState records the items an additive resource manages after a
+// failed create or update, so the ones already added are not orphaned.
+func (r *addTodoResource) savePartialState(ctx context.Context, plan orderResourceModel, items []todoclient.Item, state *tfsdk.State, diags *diag.Diagnostics) {
+	if !plan.additive() {
 		return
 	}
-	deleteRequest.Header.Add("Content-Type", "application/json")
 
-	client := &http.Client{}
-	res, err := client.Do(deleteRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to hit /delete endpoint to update todo list",
-			err.Error(),
-		)
+	partial := plan
+	partial.ID = types.StringValue(r.client.BaseURL())
+	partial.setTodoItems(items, nil)
+	diags.Append(state.Set(ctx, &partial)...)
+}
+
+// ModifyPlan asks the API to validate the planned items, so entries the
+// server would reject are reported at plan time against the exact element.
+func (r *addTodoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
 		return
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError(
-			"Received non-OK response from /delete endpoint",
-			fmt.Sprintf("Status code: %d", res.StatusCode),
-		)
+	var todoList types.List
+	diags := req.Plan.GetAttribute(ctx, path.Root("todo_list"), &todoList)
+	resp.Diagnostics.Append(diags...)
+	var todoSet types.Set
+	diags = req.Plan.GetAttribute(ctx, path.Root("todo_set"), &todoSet)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state := orderResourceModel{}
-	err = json.NewDecoder(res.Body).Decode(&state.TodoList)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read the response body",
-			fmt.Sprint(state.TodoList, "\n", err.Error()),
-		)
+	var (
+		items    todoItemCollection = todoList
+		itemPath                    = listItemPaths(path.Root("todo_list"))
+	)
+	if !todoSet.IsNull() {
+		items = todoSet
+		itemPath = setItemPaths(path.Root("todo_set"), todoSet)
+	}
+
+	// Values computed from other resources are validated on apply
+	if items.IsNull() || !isFullyKnown(items) {
 		return
 	}
-	// Set state to the values sent in the request
-	diags := resp.State.Set(ctx, &state)
+
+	var models []todoItemModel
+	diags = items.ElementsAs(ctx, &models, false)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	payload, diags := todoItemsToAPI(itemPath, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.titlePattern != nil {
+		for i, item := range payload {
+			if !r.titlePattern.MatchString(item.Title) {
+				resp.Diagnostics.AddAttributeError(
+					itemPath(i).AtName("title"),
+					"Invalid Todo Title",
+					fmt.Sprintf("The title %q does not match the title_pattern %q configured on the provider.", item.Title, r.titlePattern),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err := r.client.Validate(ctx, payload)
+	switch {
+	case err == nil:
+	case todoclient.HasStatus(err, http.StatusNotFound),
+		todoclient.HasStatus(err, http.StatusMethodNotAllowed),
+		todoclient.HasStatus(err, http.StatusNotImplemented):
+		// The server has no validation endpoint
+	case addItemErrors(&resp.Diagnostics, itemPath, err):
+	case todoclient.HasStatus(err, http.StatusUnprocessableEntity):
+		addClientError(&resp.Diagnostics, "Invalid Todo Items", err)
+	default:
+		resp.Diagnostics.AddWarning(
+			"Unable to Validate Todo Items",
+			"The planned items could not be validated by the todo API and will be checked on apply instead.\n\n"+err.Error(),
+		)
+	}
+}
+
+// ConfigValidators requires the items in exactly one of todo_list and
+// todo_set.
+func (r *addTodoResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("todo_list"),
+			path.MatchRoot("todo_set"),
+		),
+	}
 }
 
 // Configure adds the provider configured client to the resource.
@@ -279,16 +658,17 @@ func (r *addTodoResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	baseurl, ok := req.ProviderData.(string)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected string as baseurl, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.baseurl = baseurl
+	r.client = data.client
+	r.titlePattern = data.titlePattern
 }
 
 func (r *addTodoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -296,255 +676,91 @@ func (r *addTodoResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-// package provider
-
-// import (
-// 	"bytes"
-// 	"context"
-// 	"encoding/json"
-// 	"fmt"
-// 	"io"
-// 	"net/http"
-
-// 	"github.com/hashicorp/terraform-plugin-framework/path"
-// 	"github.com/hashicorp/terraform-plugin-framework/resource"
-// 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-// 	"github.com/hashicorp/terraform-plugin-framework/types"
-// )
-
-// // Ensure the implementation satisfies the expected interfaces.
-// var (
-// 	_ resource.Resource                = &addTodoResource{}
-// 	_ resource.ResourceWithConfigure   = &addTodoResource{}
-// 	_ resource.ResourceWithImportState = &addTodoResource{}
-// )
-
-// // NewAddTodoResource is a helper function to simplify the provider implementation.
-// func NewAddTodoResource() resource.Resource {
-// 	return &addTodoResource{}
-// }
-
-// // addTodoResource is the resource implementation.
-// type addTodoResource struct {
-// 	baseurl string
-// }
-
-// // orderResourceModel maps the resource schema data.
-// type orderResourceModel struct {
-// 	TodoList []string `tfsdk:"todo_list"`
-// }
-
-// // Metadata returns the resource type name.
-// func (r *addTodoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-// 	resp.TypeName = req.ProviderTypeName + "_add_todo_items"
-// }
-
-// // Schema defines the schema for the resource.
-// func (r *addTodoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-// 	resp.Schema = schema.Schema{
-// 		Description: "Fetches the todo list.",
-// 		Attributes: map[string]schema.Attribute{
-// 			"todo_list": schema.ListAttribute{
-// 				ElementType: types.StringType,
-// 				Required:    true,
-// 			},
-// 		},
-// 	}
-// }
-
-// // Create a new resource.
-// func (r *addTodoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-// 	// Retrieve values from plan
-// 	var items orderResourceModel
-// 	diags := req.Plan.Get(ctx, &items)
-// 	resp.Diagnostics.Append(diags...)
-// 	if resp.Diagnostics.HasError() {
-// 		return
-// 	}
-
-// 	// Create new order
-// 	rb, err := json.Marshal(items.TodoList)
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to Marshal the items",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-
-// 	addRequest, err := http.NewRequest("POST", fmt.Sprintf("%s/create", r.baseurl), bytes.NewBuffer([]byte(rb)))
-
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to create new request of create endpoint",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-// 	addRequest.Header.Add("Content-Type", "application/json")
-// 	client := &http.Client{}
-// 	res, err := client.Do(addRequest)
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to hit /create endpoint",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-// 	// Map response body to model
-// 	defer res.Body.Close()
-
-// 	err = json.NewDecoder(res.Body).Decode(&items.TodoList)
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to read the response body",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-// 	// Set state to fully populated data
-// 	diags = resp.State.Set(ctx, items)
-// 	resp.Diagnostics.Append(diags...)
-// 	if resp.Diagnostics.HasError() {
-// 		return
-// 	}
-// }
-
-// // Read resource information.
-// func (r *addTodoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-// 	var state ToDoDataSourceModel
-// 	diags := req.State.Get(ctx, &state)
-// 	resp.Diagnostics.Append(diags...)
-// 	if resp.Diagnostics.HasError() {
-// 		return
-// 	}
-
-// 	res, err := http.Get(fmt.Sprintf("%s/get", r.baseurl))
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to Hit the Todo List get endpoint",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-
-// 	// Map response body to model
-// 	defer res.Body.Close()
-// 	bodyBytes, err := io.ReadAll(res.Body)
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to read the response body",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-
-// 	err = json.Unmarshal(bodyBytes, &state.TodoList)
-// 	if err != nil {
-// 		resp.Diagnostics.AddError(
-// 			"Unable to Read/Unmarshal Todo List",
-// 			err.Error(),
-// 		)
-// 		return
-// 	}
-
-// 	// Set state
-// 	diags = resp.State.Set(ctx, &state)
-// 	resp.Diagnostics.Append(diags...)
-// 	if resp.Diagnostics.HasError() {
-// 		return
-// 	}
-// }
-
-// func (r *addTodoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-// 	// // Retrieve values from plan
-// 	// var plan orderResourceModel
-// 	// diags := req.Plan.Get(ctx, &plan)
-// 	// resp.Diagnostics.Append(diags...)
-// 	// if resp.Diagnostics.HasError() {
-// 	// 	return
-// 	// }
-
-// 	// // Generate API request body from plan
-// 	// var hashicupsItems []hashicups.OrderItem
-// 	// for _, item := range plan.Items {
-// 	// 	hashicupsItems = append(hashicupsItems, hashicups.OrderItem{
-// 	// 		Coffee: hashicups.Coffee{
-// 	// 			ID: int(item.Coffee.ID.ValueInt64()),
-// 	// 		},
-// 	// 		Quantity: int(item.Quantity.ValueInt64()),
-// 	// 	})
-// 	// }
-
-// 	// // Update existing order
-// 	// _, err := r.client.UpdateOrder(plan.ID.ValueString(), hashicupsItems)
-// 	// if err != nil {
-// 	// 	resp.Diagnostics.AddError(
-// 	// 		"Error Updating HashiCups Order",
-// 	// 		"Could not update order, unexpected error: "+err.Error(),
-// 	// 	)
-// 	// 	return
-// 	// }
-
-// 	// // Fetch updated items from GetOrder as UpdateOrder items are not
-// 	// // populated.
-// 	// order, err := r.client.GetOrder(plan.ID.ValueString())
-// 	// if err != nil {
-// 	// 	resp.Diagnostics.AddError(
-// 	// 		"Error Reading HashiCups Order",
-// 	// 		"Could not read HashiCups order ID "+plan.ID.ValueString()+": "+err.Error(),
-// 	// 	)
-// 	// 	return
-// 	// }
-
-// 	// diags = resp.State.Set(ctx, plan)
-// 	// resp.Diagnostics.Append(diags...)
-// 	// if resp.Diagnostics.HasError() {
-// 	// 	return
-// 	// }
-// }
-
-// func (r *addTodoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-// 	// // Retrieve values from state
-// 	// var state orderResourceModel
-// 	// diags := req.State.Get(ctx, &state)
-// 	// resp.Diagnostics.Append(diags...)
-// 	// if resp.Diagnostics.HasError() {
-// 	// 	return
-// 	// }
-
-// 	// // Delete existing order
-// 	// err := r.client.DeleteOrder(state.ID.ValueString())
-// 	// if err != nil {
-// 	// 	resp.Diagnostics.AddError(
-// 	// 		"Error Deleting HashiCups Order",
-// 	// 		"Could not delete order, unexpected error: "+err.Error(),
-// 	// 	)
-// 	// 	return
-// 	// }
-// }
-
-// // Configure adds the provider configured client to the resource.
-// func (r *addTodoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-// 	if req.ProviderData == nil {
-// 		return
-// 	}
-
-// 	baseurl, ok := req.ProviderData.(string)
-
-// 	if !ok {
-// 		resp.Diagnostics.AddError(
-// 			"Unexpected Data Source Configure Type",
-// 			fmt.Sprintf("Expected string as baseurl, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-// 		)
-
-// 		return
-// 	}
-
-// 	r.baseurl = baseurl
-// }
-
-// func (r *addTodoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
+// todoItemNestedObject describes a todo object of todo_list and todo_set.
+// Only todo_list objects get default values: the framework cannot apply
+// defaults to several attributes of a set element, as the element stops
+// matching its configuration once the first default is set.
+func todoItemNestedObject(defaults bool) schema.NestedAttributeObject {
+	object := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"title": schema.StringAttribute{
+				Description: "Text of the todo item. Leading and trailing whitespace and Unicode normalization " +
+					"changes made by the server are not reported as differences.",
+				CustomType: titleType{},
+				Required:   true,
+				Validators: titleValidators(),
+			},
+			"description": schema.StringAttribute{
+				Description: "Longer description of the todo item.",
+				Optional:    true,
+				Validators:  descriptionValidators(),
+			},
+			"done": schema.BoolAttribute{
+				Description: "Whether the todo item is completed.",
+				Optional:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the todo item.",
+				Optional:    true,
+			},
+			"due_at": schema.StringAttribute{
+				Description: "Due date of the todo item as an RFC 3339 timestamp, such as 2024-05-01T09:30:00Z.",
+				Optional:    true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"tags": schema.ListAttribute{
+				Description: "Tags of the todo item.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+	if !defaults {
+		return object
+	}
+
+	object.Attributes["description"] = schema.StringAttribute{
+		Description: "Longer description of the todo item. Defaults to an empty string.",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString(""),
+		Validators:  descriptionValidators(),
+	}
+	object.Attributes["done"] = schema.BoolAttribute{
+		Description: "Whether the todo item is completed. Defaults to false.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	object.Attributes["priority"] = schema.Int64Attribute{
+		Description: "Priority of the todo item. Defaults to 0.",
+		Optional:    true,
+		Computed:    true,
+		Default:     int64default.StaticInt64(0),
+	}
+	object.Attributes["tags"] = schema.ListAttribute{
+		Description: "Tags of the todo item. Defaults to an empty list.",
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+	}
+	return object
+}
+
+// titleValidators rejects blank and overly long titles.
+func titleValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.UTF8LengthAtMost(maxTitleLength),
+		stringvalidator.RegexMatches(regexp.MustCompile(`\S`), "must not be blank"),
+	}
+}
+
+// descriptionValidators rejects overly long descriptions.
+func descriptionValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.UTF8LengthAtMost(maxDescriptionLength),
+	}
+}
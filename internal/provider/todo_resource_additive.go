@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"terraform-provider-custom-example/internal/todoclient"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ownership modes of customexample_add_todo_items.
+const (
+	// modeAuthoritative manages the whole todo list: updates replace it
+	// and every item read from it is recorded as managed.
+	modeAuthoritative = "authoritative"
+
+	// modeAdditive only manages the items the resource created, tracked by
+	// ID, and leaves every other item of the list alone.
+	modeAdditive = "additive"
+)
+
+// listManagedItems returns the items of the todo list with the given IDs,
+// in the order of ids. Items deleted outside of Terraform are left out.
+func (r *addTodoResource) listManagedItems(ctx context.Context, ids []string) ([]todoclient.Item, error) {
+	todoList, err := r.client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]todoclient.Item, len(todoList))
+	for _, item := range todoList {
+		byID[item.ID] = item
+	}
+
+	managed := make([]todoclient.Item, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			managed = append(managed, item)
+		}
+	}
+	return managed, nil
+}
+
+// applyAdditive makes the items with the given IDs match the planned items
+// through the per-item API, without touching any other item of the list.
+// Planned items are paired with existing ones by title first, then in
+// order, so renaming an item updates it in place; unpaired existing items
+// are deleted and unpaired planned items created.
+//
+// It returns the managed items in planned order. On error it returns the
+// items managed at the time of the failure, so they can be recorded in
+// state instead of being orphaned, and the indexes of per-item validation
+// errors refer to the planned items.
+func (r *addTodoResource) applyAdditive(ctx context.Context, planned []todoclient.Item, ids []string, ignoreCase bool) ([]todoclient.Item, error) {
+	current, err := r.listManagedItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]int, len(planned))
+	used := make([]bool, len(current))
+	for i := range planned {
+		pairs[i] = -1
+		for j := range current {
+			if !used[j] && titlesEqual(planned[i].Title, current[j].Title, ignoreCase) {
+				pairs[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i := range planned {
+		if pairs[i] >= 0 {
+			continue
+		}
+		if j := slices.Index(used, false); j >= 0 {
+			pairs[i], used[j] = j, true
+		}
+	}
+
+	// managed tracks what the resource owns as the changes are made
+	managed := slices.Clone(current)
+
+	for j, item := range current {
+		if used[j] {
+			continue
+		}
+		err := r.client.DeleteItem(ctx, item.ID)
+		if err != nil && !todoclient.HasStatus(err, http.StatusNotFound) {
+			return managed, err
+		}
+		managed = slices.DeleteFunc(managed, func(m todoclient.Item) bool { return m.ID == item.ID })
+		tflog.Debug(ctx, "Deleted todo item", map[string]any{"id": item.ID})
+	}
+
+	result := make([]todoclient.Item, len(planned))
+	for i, item := range planned {
+		if j := pairs[i]; j >= 0 {
+			existing := current[j]
+			if sameItem(item, existing) {
+				result[i] = existing
+				continue
+			}
+
+			updated, err := r.client.UpdateItem(ctx, existing.ID, item)
+			if err != nil {
+				return managed, plannedItemError(err, i)
+			}
+			result[i] = *updated
+			tflog.Debug(ctx, "Updated todo item", map[string]any{"id": existing.ID})
+			continue
+		}
+
+		created, err := r.client.CreateItem(ctx, item)
+		if err != nil {
+			return managed, plannedItemError(err, i)
+		}
+		result[i] = *created
+		managed = append(managed, *created)
+		tflog.Debug(ctx, "Created todo item", map[string]any{"id": created.ID})
+	}
+	return result, nil
+}
+
+// removeItems applies remove, such as deleting or archiving, to the items
+// with the given IDs, ignoring the ones already gone.
+func (r *addTodoResource) removeItems(ctx context.Context, ids []string, remove func(context.Context, string) error) error {
+	for _, id := range ids {
+		err := remove(ctx, id)
+		if err != nil && !todoclient.HasStatus(err, http.StatusNotFound) {
+			return err
+		}
+		tflog.Debug(ctx, "Removed todo item", map[string]any{"id": id})
+	}
+	return nil
+}
+
+// plannedItemError points the validation errors of a single item request
+// at the planned item with the given index.
+func plannedItemError(err error, index int) error {
+	var apiErr *todoclient.Error
+	if errors.As(err, &apiErr) {
+		for i := range apiErr.Items {
+			apiErr.Items[i].Index = index
+		}
+	}
+	return err
+}
+
+// sameItem reports whether a and b hold the same values, ignoring their
+// IDs.
+func sameItem(a, b todoclient.Item) bool {
+	switch {
+	case a.Title != b.Title, a.Description != b.Description, a.Done != b.Done, a.Priority != b.Priority,
+		!slices.Equal(a.Tags, b.Tags), (a.DueAt == nil) != (b.DueAt == nil):
+		return false
+	}
+	return a.DueAt == nil || a.DueAt.Equal(*b.DueAt)
+}
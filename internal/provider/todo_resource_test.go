@@ -0,0 +1,682 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"terraform-provider-custom-example/internal/todoserver"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestAccAddTodoItemsResource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = "A" },
+    {
+      title       = "B"
+      description = "second"
+      done        = true
+      priority    = 3
+      due_at      = "2024-05-01T09:30:00+02:00"
+      tags        = ["home", "urgent"]
+    },
+    { title = "C" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("customexample_add_todo_items.test", "id"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.#", "3"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.title", "A"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.done", "false"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.tags.#", "0"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.description", "second"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.done", "true"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.priority", "3"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.due_at", "2024-05-01T09:30:00+02:00"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.tags.1", "urgent"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.2.title", "C"),
+					testAccCheckTodoList(srv, "A", "B", "C"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "customexample_add_todo_items.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = "A" },
+    { title = "D", done = true },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.#", "2"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.title", "D"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.done", "true"),
+					testAccCheckTodoList(srv, "A", "D"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_drift(t *testing.T) {
+	srv := newTestServer(t)
+	config := providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "A" }, { title = "B" }]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// An item changed outside of Terraform shows up as a diff
+			{
+				PreConfig:          func() { srv.SetTitles("A", "X") },
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check:              resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.title", "X"),
+			},
+			{
+				Config: config,
+				Check:  testAccCheckTodoList(srv, "A", "B"),
+			},
+			// A list cleared outside of Terraform is created again
+			{
+				PreConfig: func() { srv.SetTitles() },
+				Config:    config,
+				Check:     testAccCheckTodoList(srv, "A", "B"),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_rejectedItem(t *testing.T) {
+	newTestServerWithOptions(t, todoserver.Options{MaxTitleLength: 5})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "A" }, { title = "Too long" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Todo Item Rejected`),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_invalidItems(t *testing.T) {
+	newTestServer(t)
+
+	for name, tc := range map[string]struct {
+		items string
+		err   string
+	}{
+		"empty list": {
+			items: `todo_list = []`,
+			err:   `list must contain at least 1 elements`,
+		},
+		"empty title": {
+			items: `todo_list = [{ title = "" }]`,
+			err:   `must not be blank`,
+		},
+		"blank title": {
+			items: `todo_set = [{ title = "A" }, { title = " " }]`,
+			err:   `must not be blank`,
+		},
+		"long title": {
+			items: fmt.Sprintf(`todo_list = [{ title = %q }]`, strings.Repeat("x", maxTitleLength+1)),
+			err:   `title UTF-8 character count must be at most 256`,
+		},
+		"long description": {
+			items: fmt.Sprintf(`todo_list = [{ title = "A", description = %q }]`, strings.Repeat("x", maxDescriptionLength+1)),
+			err:   `description UTF-8 character count must be at most\s+4096`,
+		},
+		"duplicate title": {
+			items: `todo_list = [{ title = "A" }, { title = "B" }, { title = " A" }]`,
+			err:   `Duplicate Todo Title`,
+		},
+		"duplicate title ignoring case": {
+			items: `ignore_title_case = true
+todo_set = [{ title = "a", done = true }, { title = "A" }]`,
+			err: `"A" duplicates "a"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      providerConfig + "resource \"customexample_add_todo_items\" \"test\" {\n" + tc.items + "\n}\n",
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccAddTodoItemsResource_titlePattern(t *testing.T) {
+	srv := newTestServer(t)
+	config := func(title string) string {
+		return fmt.Sprintf(`
+provider "customexample" {
+  max_retries   = 0
+  title_pattern = "^[A-Z]"
+}
+
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "Buy milk" }, { title = %q }]
+}
+`, title)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("walk the dog"),
+				ExpectError: regexp.MustCompile(`"walk the dog" does not match the title_pattern "\^\[A-Z\]"`),
+			},
+			{
+				Config: config("Walk the dog"),
+				Check:  testAccCheckTodoList(srv, "Buy milk", "Walk the dog"),
+			},
+			// Updates are checked too
+			{
+				Config:      config("walk the dog"),
+				ExpectError: regexp.MustCompile(`Invalid Todo Title`),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_invalidDueDate(t *testing.T) {
+	newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "A", due_at = "tomorrow" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Due Date`),
+			},
+		},
+	})
+}
+
+// testAccCheckTodoList verifies the todo list stored by the server.
+func testAccCheckTodoList(srv *todoserver.Server, want ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if got := srv.Titles(); !slices.Equal(got, want) {
+			return fmt.Errorf("expected server todo list %q, got %q", want, got)
+		}
+		return nil
+	}
+}
+
+func TestAccAddTodoItemsResource_paginated(t *testing.T) {
+	srv := newTestServerWithOptions(t, todoserver.Options{PageSize: 2})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			// Refreshing must read every page or the plan would not be empty
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = "A" },
+    { title = "B" },
+    { title = "C" },
+    { title = "D" },
+    { title = "E" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.#", "5"),
+					testAccCheckTodoList(srv, "A", "B", "C", "D", "E"),
+				),
+			},
+			{
+				ResourceName:      "customexample_add_todo_items.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_set(t *testing.T) {
+	srv := newTestServer(t)
+	config := providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_set = [
+    { title = "A", due_at = "2024-05-01T09:30:00+02:00" },
+    { title = "B", tags = ["x"] },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_set.#", "2"),
+					resource.TestCheckNoResourceAttr("customexample_add_todo_items.test", "todo_list.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("customexample_add_todo_items.test", "todo_set.*", map[string]string{
+						"title":  "A",
+						"due_at": "2024-05-01T09:30:00+02:00",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("customexample_add_todo_items.test", "todo_set.*", map[string]string{
+						"title":  "B",
+						"tags.#": "1",
+						"tags.0": "x",
+					}),
+				),
+			},
+			// The server returning the items in another order is not a change
+			{
+				PreConfig: func() {
+					items := srv.Items()
+					slices.Reverse(items)
+					srv.SetItems(items...)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			// A changed item still is
+			{
+				PreConfig:          func() { srv.SetTitles("B", "C") },
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testAccCheckTodoList(srv, "A", "B"),
+			},
+			// A repeated item shows once in todo_set but its ID stays
+			// managed, so destroying the resource removes it too
+			{
+				PreConfig: func() { srv.AddItems(srv.Items()[1]) },
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_set.#", "2"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "managed_item_ids.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_listOrSet(t *testing.T) {
+	newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "A" }]
+  todo_set  = [{ title = "A" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      providerConfig + `resource "customexample_add_todo_items" "test" {}`,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_normalizedTitles(t *testing.T) {
+	// Terraform normalizes configuration strings to NFC, so the server
+	// decomposes accented letters to exercise Unicode normalization
+	srv := newTestServerWithOptions(t, todoserver.Options{
+		TitleTransform: func(title string) string { return norm.NFD.String(strings.TrimSpace(title)) },
+	})
+	config := providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = " Buy milk " },
+    { title = "Caf\u00e9" },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			// The titles are kept as written, without an inconsistent result
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.title", " Buy milk "),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.title", "Caf\u00e9"),
+					testAccCheckTodoList(srv, "Buy milk", "Cafe\u0301"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_ignoreTitleCase(t *testing.T) {
+	srv := newTestServerWithOptions(t, todoserver.Options{TitleTransform: strings.ToLower})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  ignore_title_case = true
+  todo_list         = [{ title = "Buy Milk" }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.title", "Buy Milk"),
+					testAccCheckTodoList(srv, "buy milk"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  ignore_title_case = true
+  todo_set          = [{ title = "Buy Milk" }, { title = "Walk The Dog" }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("customexample_add_todo_items.test", "todo_set.*", map[string]string{
+						"title": "Walk The Dog",
+					}),
+					testAccCheckTodoList(srv, "buy milk", "walk the dog"),
+				),
+			},
+			// Without ignore_title_case the lowercased titles are a change
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_set = [{ title = "Buy Milk" }, { title = "Walk The Dog" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Provider produced inconsistent result`),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_additive(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetTitles("Foreign")
+	config := func(items string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "customexample_add_todo_items" "test" {
+  mode = "additive"
+  %s
+}
+`, items)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Destroying the resource leaves the items it did not add
+		CheckDestroy: testAccCheckTodoList(srv, "Foreign", "Other"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`todo_list = [{ title = "A" }, { title = "B" }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "mode", "additive"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.#", "2"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "managed_item_ids.#", "2"),
+					testAccCheckTodoList(srv, "Foreign", "A", "B"),
+				),
+			},
+			// Items added by someone else are not a change
+			{
+				PreConfig: func() { srv.AddItems(todoserver.Item{Title: "Other"}) },
+				Config:    config(`todo_list = [{ title = "A" }, { title = "B" }]`),
+				PlanOnly:  true,
+			},
+			// Only the managed items change; B is renamed in place
+			{
+				Config: config(`todo_list = [{ title = "A", done = true }, { title = "C" }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0.done", "true"),
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "managed_item_ids.#", "2"),
+					testAccCheckTodoList(srv, "Foreign", "A", "C", "Other"),
+				),
+			},
+			{
+				Config: config(`todo_set = [{ title = "C" }, { title = "A", done = true }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_set.#", "2"),
+					testAccCheckTodoList(srv, "Foreign", "A", "C", "Other"),
+				),
+			},
+			// A managed item deleted outside of Terraform is added again
+			{
+				PreConfig: func() {
+					for _, item := range srv.Items() {
+						if item.Title == "C" {
+							srv.DeleteItem(item.ID)
+						}
+					}
+				},
+				Config: config(`todo_set = [{ title = "C" }, { title = "A", done = true }]`),
+				Check:  testAccCheckTodoList(srv, "Foreign", "A", "Other", "C"),
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_modeChange(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  mode      = "additive"
+  todo_list = [{ title = "A" }]
+}
+`,
+				Check: testAccCheckTodoList(srv, "A"),
+			},
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  mode      = "additive"
+  todo_list = [{ title = "A" }]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [{ title = "A" }]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("customexample_add_todo_items.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: testAccCheckTodoList(srv, "A"),
+			},
+			{
+				ResourceName:      "customexample_add_todo_items.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAddTodoItemsResource_deleteBehavior(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mode      string
+		behavior  string
+		remaining []string
+		archived  []string
+	}{
+		"additive/clear_all":                 {mode: modeAdditive, behavior: deleteClearAll},
+		"additive/remove_managed_items":      {mode: modeAdditive, behavior: deleteRemoveManagedItems, remaining: []string{"Foreign"}},
+		"additive/archive":                   {mode: modeAdditive, behavior: deleteArchive, remaining: []string{"Foreign"}, archived: []string{"A", "B"}},
+		"additive/abandon":                   {mode: modeAdditive, behavior: deleteAbandon, remaining: []string{"Foreign", "A", "B"}},
+		"authoritative/clear_all":            {mode: modeAuthoritative, behavior: deleteClearAll},
+		"authoritative/remove_managed_items": {mode: modeAuthoritative, behavior: deleteRemoveManagedItems},
+		"authoritative/archive":              {mode: modeAuthoritative, behavior: deleteArchive, archived: []string{"A", "B"}},
+		"authoritative/abandon":              {mode: modeAuthoritative, behavior: deleteAbandon, remaining: []string{"A", "B"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := newTestServer(t)
+
+			// An authoritative resource would take over the foreign item
+			created := []string{"A", "B"}
+			if tc.mode == modeAdditive {
+				srv.SetTitles("Foreign")
+				created = []string{"Foreign", "A", "B"}
+			}
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTodoList(srv, tc.remaining...),
+					func(_ *terraform.State) error {
+						var archived []string
+						for _, item := range srv.Archived() {
+							archived = append(archived, item.Title)
+						}
+						if !slices.Equal(archived, tc.archived) {
+							return fmt.Errorf("expected archived items %q, got %q", tc.archived, archived)
+						}
+						return nil
+					},
+				),
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + fmt.Sprintf(`
+resource "customexample_add_todo_items" "test" {
+  mode            = %q
+  delete_behavior = %q
+  todo_list       = [{ title = "A" }, { title = "B" }]
+}
+`, tc.mode, tc.behavior),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("customexample_add_todo_items.test", "delete_behavior", tc.behavior),
+							testAccCheckTodoList(srv, created...),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccAddTodoItemsResource_deleteWithoutIDs(t *testing.T) {
+	srv := newTestServerWithOptions(t, todoserver.Options{BareTitles: true})
+	config := func(behavior string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "customexample_add_todo_items" "test" {
+  delete_behavior = %q
+  todo_list       = [{ title = "A" }, { title = "B" }]
+}
+`, behavior)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTodoList(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: config(deleteRemoveManagedItems),
+				Check:  testAccCheckTodoList(srv, "A", "B"),
+			},
+			// Items without IDs cannot be deleted one by one, which must
+			// fail rather than leave them on the server
+			{
+				Config:      config(deleteRemoveManagedItems),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`did not return the IDs of the managed items`),
+			},
+			{
+				Config: config(deleteArchive),
+				Check:  testAccCheckTodoList(srv, "A", "B"),
+			},
+			{
+				Config:      config(deleteArchive),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Unable to Archive Todo Items`),
+			},
+			// Emptying the whole list needs no IDs
+			{
+				Config: config(deleteClearAll),
+			},
+		},
+	})
+}
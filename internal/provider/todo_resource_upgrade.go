@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// orderResourceModelV0 maps the data of customexample_add_todo_items
+// states written with schema version 0, when todo_list held bare titles.
+type orderResourceModelV0 struct {
+	ID       types.String   `tfsdk:"id"`
+	TodoList []string       `tfsdk:"todo_list"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// UpgradeState upgrades the states written by prior schema versions.
+func (r *addTodoResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 held todo_list as a list of titles. id and timeouts
+		// were added later, so older states lack them.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"todo_list": schema.ListAttribute{
+						ElementType: types.StringType,
+						Required:    true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+// upgradeStateV0 turns every title of a version 0 todo_list into an item
+// holding the defaults of the other attributes. States written before id
+// existed get the base URL of the configured API.
+func (r *addTodoResource) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior orderResourceModelV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	todoList := make([]todoItemModel, 0, len(prior.TodoList))
+	for _, title := range prior.TodoList {
+		todoList = append(todoList, todoItemModel{
+			Title:       newTitleValue(title),
+			Description: types.StringValue(""),
+			Done:        types.BoolValue(false),
+			Priority:    types.Int64Value(0),
+			DueAt:       types.StringNull(),
+			Tags:        []string{},
+		})
+	}
+
+	if prior.ID.IsNull() && r.client != nil {
+		prior.ID = types.StringValue(r.client.BaseURL())
+	}
+
+	// managed_item_ids is left null: the next refresh records the IDs of
+	// the items read from the server
+	state := orderResourceModel{
+		ID:              prior.ID,
+		TodoList:        todoList,
+		IgnoreTitleCase: types.BoolNull(),
+		Mode:            types.StringValue(modeAuthoritative),
+		ManagedItemIDs:  types.ListNull(types.StringType),
+		DeleteBehavior:  types.StringValue(deleteRemoveManagedItems),
+		Timeouts:        prior.Timeouts,
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"terraform-provider-custom-example/internal/todoclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// schemaV0Provider is the provider with customexample_add_todo_items
+// implemented as in schema version 0, for writing states to upgrade.
+type schemaV0Provider struct {
+	provider.Provider
+}
+
+func (p schemaV0Provider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource { return &addTodoResourceV0{} },
+	}
+}
+
+// addTodoResourceV0 implements customexample_add_todo_items as first
+// released, with todo_list as a list of titles and no id or timeouts.
+type addTodoResourceV0 struct {
+	client *todoclient.Client
+}
+
+// addTodoResourceModelV0 maps the data of addTodoResourceV0.
+type addTodoResourceModelV0 struct {
+	TodoList []string `tfsdk:"todo_list"`
+}
+
+func (r *addTodoResourceV0) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_add_todo_items"
+}
+
+func (r *addTodoResourceV0) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"todo_list": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *addTodoResourceV0) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if data, ok := req.ProviderData.(*resourceData); ok {
+		r.client = data.client
+	}
+}
+
+func (r *addTodoResourceV0) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan addTodoResourceModelV0
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items := make([]todoclient.Item, 0, len(plan.TodoList))
+	for _, title := range plan.TodoList {
+		items = append(items, todoclient.Item{Title: title})
+	}
+	if _, err := r.client.Create(ctx, items); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Todo Items", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *addTodoResourceV0) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+	// The state written by Create is all the test needs
+}
+
+func (r *addTodoResourceV0) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Unsupported Update", "The schema version 0 test resource cannot be updated.")
+}
+
+func (r *addTodoResourceV0) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if err := r.client.DeleteAll(ctx); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Todo Items", err.Error())
+	}
+}
+
+func TestAccAddTodoItemsResource_upgradeFromV0(t *testing.T) {
+	srv := newTestServer(t)
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		CheckDestroy: testAccCheckTodoList(srv),
+		Steps: []resourcetest.TestStep{
+			// Write a state where todo_list holds bare titles
+			{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"customexample": providerserver.NewProtocol6WithError(schemaV0Provider{New("test")()}),
+				},
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = ["A", "B"]
+}
+`,
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.0", "A"),
+					resourcetest.TestCheckNoResourceAttr("customexample_add_todo_items.test", "id"),
+					testAccCheckTodoList(srv, "A", "B"),
+				),
+			},
+			// The upgraded state matches the same items written as objects
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = "A" },
+    { title = "B" },
+  ]
+}
+`,
+				ConfigPlanChecks: resourcetest.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "id", os.Getenv("CUSTOM_EXAMPLE_BASEURL")),
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.#", "2"),
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.title", "B"),
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "todo_list.1.done", "false"),
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "mode", modeAuthoritative),
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "managed_item_ids.#", "2"),
+					testAccCheckTodoList(srv, "A", "B"),
+				),
+			},
+			// Updating a resource first created without id keeps it known
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config: providerConfig + `
+resource "customexample_add_todo_items" "test" {
+  todo_list = [
+    { title = "A" },
+    { title = "B" },
+    { title = "C" },
+  ]
+}
+`,
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("customexample_add_todo_items.test", "id", os.Getenv("CUSTOM_EXAMPLE_BASEURL")),
+					testAccCheckTodoList(srv, "A", "B", "C"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// rfc3339Validator checks that a string is an RFC 3339 timestamp.
+type rfc3339Validator struct{}
+
+var _ validator.String = rfc3339Validator{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp, such as 2024-05-01T09:30:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Due Date",
+			fmt.Sprintf("The %s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// regexpValidator checks that a string is a valid RE2 regular expression.
+type regexpValidator struct{}
+
+var _ validator.String = regexpValidator{}
+
+func (v regexpValidator) Description(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The %s: %s", v.Description(ctx), err),
+		)
+	}
+}
+
+// uniqueTitlesValidator checks that no two items of a todo_list or
+// todo_set have the same title once normalized, ignoring case when the
+// ignore_title_case attribute of the resource is set.
+type uniqueTitlesValidator struct{}
+
+var (
+	_ validator.List = uniqueTitlesValidator{}
+	_ validator.Set  = uniqueTitlesValidator{}
+)
+
+func (v uniqueTitlesValidator) Description(_ context.Context) string {
+	return "item titles must be unique"
+}
+
+func (v uniqueTitlesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueTitlesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	elements := req.ConfigValue.Elements()
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, elements, func(i int) path.Path {
+		return req.Path.AtListIndex(i)
+	})...)
+}
+
+func (v uniqueTitlesValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	elements := req.ConfigValue.Elements()
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, elements, func(i int) path.Path {
+		return req.Path.AtSetValue(elements[i])
+	})...)
+}
+
+// validate reports every item whose title is already used by an earlier
+// item. Items with an unknown title are skipped.
+func (v uniqueTitlesValidator) validate(ctx context.Context, config tfsdk.Config, elements []attr.Value, itemPath itemPathFunc) diag.Diagnostics {
+	var ignoreCase types.Bool
+	diags := config.GetAttribute(ctx, path.Root("ignore_title_case"), &ignoreCase)
+	if diags.HasError() {
+		return diags
+	}
+
+	var titles []string
+	for i, element := range elements {
+		item, ok := element.(types.Object)
+		if !ok || item.IsNull() || item.IsUnknown() {
+			continue
+		}
+		title, ok := item.Attributes()["title"].(basetypes.StringValuable)
+		if !ok {
+			continue
+		}
+		value, d := title.ToStringValue(ctx)
+		diags.Append(d...)
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		first := slices.IndexFunc(titles, func(t string) bool {
+			return titlesEqual(t, value.ValueString(), ignoreCase.ValueBool())
+		})
+		if first >= 0 {
+			diags.AddAttributeError(
+				itemPath(i).AtName("title"),
+				"Duplicate Todo Title",
+				fmt.Sprintf("The %s, but %q duplicates %q.", v.Description(ctx), value.ValueString(), titles[first]),
+			)
+		}
+		titles = append(titles, value.ValueString())
+	}
+	return diags
+}
@@ -0,0 +1,122 @@
+package todoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenExpiryDelta is subtracted from the token lifetime so a token is
+// refreshed shortly before the server starts rejecting it. Tokens living
+// less than twice as long lose half their lifetime instead.
+const tokenExpiryDelta = 30 * time.Second
+
+// loginRequest is the body sent to the login endpoint.
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loginResponse is the body returned by the login endpoint. ExpiresIn is
+// the token lifetime in seconds; zero means the token does not expire.
+type loginResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// tokenSource exchanges the configured credentials for a bearer token and
+// caches it until it expires or is rejected by the API.
+type tokenSource struct {
+	httpClient *http.Client
+	loginURL   string
+	username   string
+	password   string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// Token returns the cached token, logging in first when there is no valid
+// token.
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && (ts.expiry.IsZero() || time.Now().Before(ts.expiry)) {
+		return ts.token, nil
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Logging in to todo API", map[string]any{
+		"login_url": ts.loginURL,
+	})
+	res, err := ts.login(ctx)
+	if err != nil {
+		return "", err
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Logged in to todo API", map[string]any{
+		"expires_in": res.ExpiresIn,
+	})
+
+	ts.token = res.Token
+	ts.expiry = time.Time{}
+	if res.ExpiresIn > 0 {
+		lifetime := time.Duration(res.ExpiresIn) * time.Second
+		ts.expiry = time.Now().Add(lifetime - min(tokenExpiryDelta, lifetime/2))
+	}
+	return ts.token, nil
+}
+
+// Invalidate drops token from the cache so the next call to Token logs in
+// again. It is a no-op when the cache already holds a newer token.
+func (ts *tokenSource) Invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = ""
+		ts.expiry = time.Time{}
+	}
+}
+
+func (ts *tokenSource) login(ctx context.Context) (*loginResponse, error) {
+	rb, err := json.Marshal(loginRequest{
+		Username: ts.username,
+		Password: ts.password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.loginURL, bytes.NewReader(rb))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create login request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := ts.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach login endpoint: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, newError(http.MethodPost, req.URL.Path, res)
+	}
+
+	var out loginResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("unable to decode login response: %w", err)
+	}
+	if out.Token == "" {
+		return nil, fmt.Errorf("login response did not contain a token")
+	}
+	return &out, nil
+}
@@ -0,0 +1,381 @@
+// Package todoclient implements a client for the todo list API managed by
+// the custom example provider.
+package todoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Config holds the settings used to build a Client.
+type Config struct {
+	// BaseURL is the root URL of the todo API, e.g. http://localhost:8080.
+	BaseURL string
+
+	// Username and Password are sent as HTTP Basic credentials with every
+	// request when Username is not empty and LoginURL is empty.
+	Username string
+	Password string
+
+	// LoginURL switches the client to token authentication. Username and
+	// Password are exchanged for a bearer token at this endpoint, which may
+	// be an absolute URL or a path relative to BaseURL.
+	LoginURL string
+
+	// MaxRetries is how many times a failed request is retried. Zero
+	// disables retries.
+	MaxRetries int
+
+	// RetryWaitMin and RetryWaitMax bound the wait between two attempts.
+	// They default to DefaultRetryWaitMin and DefaultRetryWaitMax.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// Timeout bounds a single HTTP request, including reading the
+	// response. Zero means no timeout.
+	Timeout time.Duration
+
+	// PageSize is the number of items requested per page when listing.
+	// Zero leaves the page size to the server.
+	PageSize int
+
+	// MaxResponseSize caps the size in bytes of a single response body,
+	// so a misbehaving server cannot exhaust memory. It defaults to
+	// DefaultMaxResponseSize.
+	MaxResponseSize int64
+
+	// TLS configures how connections to the API are secured.
+	TLS TLSConfig
+
+	// ProxyURL and NoProxy override the HTTP_PROXY/HTTPS_PROXY and NO_PROXY
+	// environment variables when they are not empty.
+	ProxyURL string
+	NoProxy  string
+
+	// Headers are added to every request, including logins. They never
+	// replace headers set by the client itself such as Authorization.
+	Headers map[string]string
+
+	// HTTPClient is used to send the requests instead of a client built
+	// from Timeout, TLS, ProxyURL, NoProxy and Headers. It is mainly useful
+	// for tests.
+	HTTPClient *http.Client
+}
+
+// Client talks to the todo API.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	tokens     *tokenSource
+	httpClient *http.Client
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	pageSize        int
+	maxResponseSize int64
+}
+
+// NewClient returns a Client for the given configuration.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("base URL must not be empty")
+	}
+
+	if cfg.MaxRetries < 0 {
+		return nil, fmt.Errorf("max retries must not be negative, got %d", cfg.MaxRetries)
+	}
+
+	if cfg.PageSize < 0 {
+		return nil, fmt.Errorf("page size must not be negative, got %d", cfg.PageSize)
+	}
+
+	maxResponseSize := cfg.MaxResponseSize
+	if maxResponseSize < 0 {
+		return nil, fmt.Errorf("maximum response size must not be negative, got %d", maxResponseSize)
+	}
+	if maxResponseSize == 0 {
+		maxResponseSize = DefaultMaxResponseSize
+	}
+
+	retryWaitMin := cfg.RetryWaitMin
+	if retryWaitMin <= 0 {
+		retryWaitMin = DefaultRetryWaitMin
+	}
+	retryWaitMax := cfg.RetryWaitMax
+	if retryWaitMax <= 0 {
+		retryWaitMax = DefaultRetryWaitMax
+	}
+	if retryWaitMin > retryWaitMax {
+		return nil, fmt.Errorf("minimum retry wait %s is greater than maximum retry wait %s", retryWaitMin, retryWaitMax)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		transport, err := newTransport(cfg)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{
+			Transport: transport,
+			Timeout:   cfg.Timeout,
+		}
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: httpClient,
+
+		maxRetries:   cfg.MaxRetries,
+		retryWaitMin: retryWaitMin,
+		retryWaitMax: retryWaitMax,
+
+		pageSize:        cfg.PageSize,
+		maxResponseSize: maxResponseSize,
+	}
+
+	if cfg.LoginURL != "" {
+		loginURL := cfg.LoginURL
+		if !strings.Contains(loginURL, "://") {
+			loginURL = c.baseURL + "/" + strings.TrimLeft(loginURL, "/")
+		}
+		c.tokens = &tokenSource{
+			httpClient: httpClient,
+			loginURL:   loginURL,
+			username:   cfg.Username,
+			password:   cfg.Password,
+		}
+	}
+
+	return c, nil
+}
+
+// Login fetches a bearer token when the client uses token authentication,
+// so invalid credentials are reported before any other call is made. It is
+// a no-op for Basic authentication.
+func (c *Client) Login(ctx context.Context) error {
+	if c.tokens == nil {
+		return nil
+	}
+	_, err := c.tokens.Token(c.logContext(ctx))
+	return err
+}
+
+// BaseURL returns the root URL of the todo API the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// List returns every item of the todo list, following pagination.
+func (c *Client) List(ctx context.Context) ([]Item, error) {
+	items, _, err := c.listAll(ctx, nil)
+	return items, err
+}
+
+// Create adds the given items to the todo list and returns the resulting list.
+func (c *Client) Create(ctx context.Context, items []Item) ([]Item, error) {
+	var result []Item
+	if err := c.do(ctx, http.MethodPost, "/create", items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Replace overwrites the todo list with the given items and returns the
+// resulting list.
+func (c *Client) Replace(ctx context.Context, items []Item) ([]Item, error) {
+	var result []Item
+	if err := c.do(ctx, http.MethodPut, "/update", items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validate asks the server whether it would accept items without changing
+// the todo list. Servers without a validation endpoint answer 404, 405 or
+// 501, which callers should treat as "not supported".
+func (c *Client) Validate(ctx context.Context, items []Item) error {
+	return c.do(ctx, http.MethodPost, "/validate", items, nil)
+}
+
+// DeleteAll removes every item of the todo list.
+func (c *Client) DeleteAll(ctx context.Context) error {
+	return c.do(ctx, http.MethodDelete, "/delete", nil, nil)
+}
+
+// do sends a request to the API and decodes the JSON response into out.
+// The request body is omitted when in is nil and the response body is
+// discarded when out is nil.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	_, err := c.doWithHeader(ctx, method, path, in, out)
+	return err
+}
+
+// doWithHeader is like do but also returns the headers of a successful
+// response.
+func (c *Client) doWithHeader(ctx context.Context, method, path string, in, out any) (http.Header, error) {
+	ctx = c.logContext(ctx)
+
+	var rb []byte
+	if in != nil {
+		var err error
+		rb, err = json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal request body: %w", err)
+		}
+	}
+
+	res, err := c.sendWithRetry(ctx, method, path, rb)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, newError(method, path, res)
+	}
+
+	if out == nil {
+		return res.Header, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, c.maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response of %s %s: %w", method, path, err)
+	}
+	if int64(len(body)) > c.maxResponseSize {
+		return nil, fmt.Errorf("response of %s %s exceeds the maximum size of %d bytes", method, path, c.maxResponseSize)
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "Received todo API response body", map[string]any{
+		"method": method,
+		"path":   path,
+		"body":   string(body),
+	})
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("unable to decode response of %s %s: %w", method, path, err)
+	}
+	return res.Header, nil
+}
+
+// sendWithRetry sends a request, retrying it with backoff while the
+// failure is transient and the retry budget is not exhausted.
+func (c *Client) sendWithRetry(ctx context.Context, method, path string, rb []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		ctx := tflog.SubsystemSetField(ctx, logSubsystem, "attempt", attempt+1)
+
+		res, err := c.sendWithReauth(ctx, method, path, rb)
+		if attempt >= c.maxRetries || !shouldRetry(ctx, method, res, err) {
+			return res, err
+		}
+
+		wait := c.backoff(attempt, res)
+		fields := map[string]any{
+			"method":   method,
+			"path":     path,
+			"wait":     wait.String(),
+			"attempts": c.maxRetries + 1,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		if res != nil {
+			fields["status"] = res.StatusCode
+			drain(res)
+		}
+		tflog.SubsystemWarn(ctx, logSubsystem, "Retrying todo API request", fields)
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("%s %s canceled while waiting to retry: %w", method, path, err)
+		}
+	}
+}
+
+// sendWithReauth sends a request once. With token authentication a cached
+// token may have been revoked or expired early on the server, so on a 401
+// it logs in again and resends the request before giving up.
+func (c *Client) sendWithReauth(ctx context.Context, method, path string, rb []byte) (*http.Response, error) {
+	res, token, err := c.send(ctx, method, path, rb)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && c.tokens != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Todo API rejected the bearer token, logging in again")
+		drain(res)
+		c.tokens.Invalidate(token)
+
+		res, _, err = c.send(ctx, method, path, rb)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// send builds and sends a single authenticated request. It returns the
+// bearer token used, if any, so callers can invalidate it on a 401.
+func (c *Client) send(ctx context.Context, method, path string, rb []byte) (*http.Response, string, error) {
+	var body io.Reader
+	if rb != nil {
+		body = bytes.NewReader(rb)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create request for %s %s: %w", method, path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if rb != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	var token string
+	switch {
+	case c.tokens != nil:
+		token, err = c.tokens.Token(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to log in to the todo API: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	if rb != nil {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Sending todo API request body", map[string]any{
+			"method": method,
+			"path":   path,
+			"body":   string(rb),
+		})
+	}
+
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	fields := map[string]any{
+		"method":      method,
+		"url":         req.URL.String(),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, logSubsystem, "Todo API request failed", fields)
+		return nil, "", fmt.Errorf("unable to reach %s %s: %w", method, path, err)
+	}
+
+	fields["status"] = res.StatusCode
+	tflog.SubsystemDebug(ctx, logSubsystem, "Todo API request completed", fields)
+	return res, token, nil
+}
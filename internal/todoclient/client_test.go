@@ -0,0 +1,323 @@
+package todoclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, cfg Config) *Client {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	cfg.BaseURL = ts.URL
+	if cfg.RetryWaitMin == 0 {
+		cfg.RetryWaitMin = time.Millisecond
+		cfg.RetryWaitMax = 10 * time.Millisecond
+	}
+
+	c, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func TestClientList_basicAuth(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"1","title":"A"},{"id":"2","title":"B"}]`))
+	}, Config{Username: "user", Password: "pass"})
+
+	items, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if want := []string{"A", "B"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected %q, got %q", want, titles(items))
+	}
+}
+
+func TestClientList_itemFields(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id":"1","title":"A","description":"first","done":true,"priority":2,"due_at":"2024-05-01T09:30:00+02:00","tags":["x","y"]},
+			"B"
+		]`))
+	}, Config{})
+
+	items, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	dueAt := time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC)
+	got := items[0]
+	if got.ID != "1" || got.Title != "A" || got.Description != "first" || !got.Done || got.Priority != 2 ||
+		got.DueAt == nil || !got.DueAt.Equal(dueAt) || !slices.Equal(got.Tags, []string{"x", "y"}) {
+		t.Errorf("unexpected item: %+v", got)
+	}
+
+	// Older servers return bare titles
+	if want := (Item{Title: "B"}); items[1].Title != want.Title || items[1].ID != "" || items[1].Tags != nil {
+		t.Errorf("expected %+v, got %+v", want, items[1])
+	}
+}
+
+func TestClientList_errorPayload(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"code":"conflict","message":"list is locked","field":"todo_list"}}`))
+	}, Config{})
+
+	_, err := c.List(context.Background())
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Code != "conflict" || apiErr.Message != "list is locked" ||
+		apiErr.Field != "todo_list" || apiErr.RequestID != "req-1" {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+}
+
+func TestClientList_retriesWithRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`["A"]`))
+		}
+	}, Config{MaxRetries: 3})
+
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestClientCreate_doesNotRetryServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, Config{MaxRetries: 3})
+
+	_, err := c.Create(context.Background(), []Item{{Title: "A"}})
+	if !HasStatus(err, http.StatusInternalServerError) {
+		t.Fatalf("expected status 500 error, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestClientList_tokenRefreshOn401(t *testing.T) {
+	var logins atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			if logins.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"token":"stale"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"fresh","expires_in":3600}`))
+		case "/get":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}, Config{Username: "user", Password: "pass", LoginURL: "/login"})
+
+	if err := c.Login(context.Background()); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := logins.Load(); got != 2 {
+		t.Errorf("expected 2 logins, got %d", got)
+	}
+}
+
+func TestClientList_shortLivedTokenIsCached(t *testing.T) {
+	var logins atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			logins.Add(1)
+			_, _ = w.Write([]byte(`{"token":"short","expires_in":10}`))
+		case "/get":
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}, Config{Username: "user", Password: "pass", LoginURL: "/login"})
+
+	for range 3 {
+		if _, err := c.List(context.Background()); err != nil {
+			t.Fatalf("List: %v", err)
+		}
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("expected a token living 10s to be reused, got %d logins", got)
+	}
+}
+
+func titles(items []Item) []string {
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestClientQuery_serverSide(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set(QueryAppliedHeader, "true")
+		_, _ = w.Write([]byte(`[{"title":"B"}]`))
+	}, Config{})
+
+	done := false
+	items, err := c.Query(context.Background(), Query{TitlePrefix: "B", Done: &done, SortBy: SortByTitle, Limit: 1})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if want := "done=false&limit=1&sort=title&title_prefix=B"; query != want {
+		t.Errorf("expected query %q, got %q", want, query)
+	}
+	if want := []string{"B"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected %q, got %q", want, titles(items))
+	}
+}
+
+func TestClientQuery_clientSideFallback(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"title":"b2","priority":2,"tags":["x"]},
+			{"title":"a1","priority":1,"tags":["x"]},
+			{"title":"c3","priority":3,"tags":["x"],"done":true},
+			{"title":"d4","priority":0}
+		]`))
+	}, Config{})
+
+	done := false
+	items, err := c.Query(context.Background(), Query{
+		TitleRegex: `^[a-c]`,
+		Tag:        "x",
+		Done:       &done,
+		SortBy:     SortByPriority,
+		Offset:     1,
+	})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if want := []string{"b2"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected %q, got %q", want, titles(items))
+	}
+}
+
+func TestClientQuery_invalidRegex(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}, Config{})
+
+	if _, err := c.Query(context.Background(), Query{TitleRegex: "("}); err == nil {
+		t.Fatal("expected an error for an invalid regular expression")
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("expected no request, got %d", got)
+	}
+}
+
+func TestClientList_cursorPagination(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"items":[{"title":"A"},{"title":"B"}],"next":"c2"}`))
+		case "c2":
+			_, _ = w.Write([]byte(`{"items":[{"title":"C"}],"next":""}`))
+		}
+	}, Config{PageSize: 2})
+
+	items, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if want := []string{"A", "B", "C"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected %q, got %q", want, titles(items))
+	}
+	if want := []string{"page_size=2", "cursor=c2&page_size=2"}; !slices.Equal(queries, want) {
+		t.Errorf("expected queries %q, got %q", want, queries)
+	}
+}
+
+func TestClientList_linkPagination(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</get?page=2>; rel="next", </get>; rel="first"`)
+			_, _ = w.Write([]byte(`[{"title":"A"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"title":"B"}]`))
+		}
+	}, Config{})
+
+	items, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if want := []string{"A", "B"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected %q, got %q", want, titles(items))
+	}
+}
+
+func TestClientList_linkOutsideAPI(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://elsewhere.example.com/get?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"title":"A"}]`))
+	}, Config{})
+
+	if _, err := c.List(context.Background()); err == nil {
+		t.Fatal("expected an error for a next page outside of the API")
+	}
+}
+
+func TestClientList_maxResponseSize(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"title":"` + strings.Repeat("A", 100) + `"}]`))
+	}, Config{MaxResponseSize: 64})
+
+	_, err := c.List(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum size of 64 bytes") {
+		t.Fatalf("expected a response size error, got %v", err)
+	}
+}
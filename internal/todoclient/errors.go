@@ -0,0 +1,114 @@
+package todoclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize caps how much of an error response body is kept.
+const maxErrorBodySize = 4096
+
+// Error is returned when the API answers with a non-2xx status code. The
+// Code, Message, Field and RequestID fields are filled from the JSON error
+// payload when the server sends one; Body always holds the raw response.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+
+	Code      string
+	Message   string
+	Field     string
+	RequestID string
+
+	// Items lists the entries of a submitted todo list that the server
+	// rejected, when it reports them individually.
+	Items []ItemError
+
+	Body string
+}
+
+// ItemError describes why the server rejected one entry of a todo list.
+// Index is the zero-based position of the entry in the submitted list.
+type ItemError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// errorPayload is the JSON error body sent by the API, either at the top
+// level or wrapped in an "error" object.
+type errorPayload struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Field     string      `json:"field"`
+	RequestID string      `json:"request_id"`
+	Items     []ItemError `json:"items"`
+}
+
+func newError(method, path string, res *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+	apiErr := &Error{
+		Method:     method,
+		Path:       path,
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("X-Request-Id"),
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	var payload struct {
+		errorPayload
+		Error *errorPayload `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		p := payload.errorPayload
+		if payload.Error != nil {
+			p = *payload.Error
+		}
+		apiErr.Code = p.Code
+		apiErr.Message = p.Message
+		apiErr.Field = p.Field
+		apiErr.Items = p.Items
+		if p.RequestID != "" {
+			apiErr.RequestID = p.RequestID
+		}
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s returned status %d", e.Method, e.Path, e.StatusCode)
+	if e.Code != "" {
+		msg += " (" + e.Code + ")"
+	}
+
+	switch {
+	case e.Message != "":
+		msg += ": " + e.Message
+	case e.Body != "" && !strings.HasPrefix(e.Body, "<"):
+		// HTML error pages from proxies are noise, leave them out
+		msg += ": " + e.Body
+	}
+
+	for _, item := range e.Items {
+		msg += fmt.Sprintf(" [item %d: %s]", item.Index, item.Message)
+	}
+	if e.Field != "" {
+		msg += fmt.Sprintf(" [field: %s]", e.Field)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request ID: %s]", e.RequestID)
+	}
+	return msg
+}
+
+// HasStatus reports whether err is an *Error with the given status code.
+func HasStatus(err error, statusCode int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,82 @@
+package todoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Item is a single todo. ID is assigned by the server when the item is
+// created and is ignored in list payloads sent to the server.
+type Item struct {
+	ID          string     `json:"id,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Done        bool       `json:"done"`
+	Priority    int64      `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+// UnmarshalJSON decodes an item object. A bare string is accepted as an
+// item with only a title, which is how older servers return the list.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var title string
+	if err := json.Unmarshal(data, &title); err == nil {
+		*i = Item{Title: title}
+		return nil
+	}
+
+	// The alias drops this method so the object is decoded field by field
+	type item Item
+	var v item
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*i = Item(v)
+	return nil
+}
+
+// CreateItem adds a single item and returns it with its server assigned ID.
+func (c *Client) CreateItem(ctx context.Context, item Item) (*Item, error) {
+	var result Item
+	if err := c.do(ctx, http.MethodPost, "/items", item, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetItem returns the item with the given ID.
+func (c *Client) GetItem(ctx context.Context, id string) (*Item, error) {
+	var result Item
+	if err := c.do(ctx, http.MethodGet, itemPath(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateItem overwrites the item with the given ID and returns the result.
+func (c *Client) UpdateItem(ctx context.Context, id string, item Item) (*Item, error) {
+	var result Item
+	if err := c.do(ctx, http.MethodPut, itemPath(id), item, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteItem removes the item with the given ID.
+func (c *Client) DeleteItem(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, itemPath(id), nil, nil)
+}
+
+// ArchiveItem moves the item with the given ID out of the todo list into
+// the archive of the server.
+func (c *Client) ArchiveItem(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, itemPath(id)+"/archive", nil, nil)
+}
+
+func itemPath(id string) string {
+	return "/items/" + url.PathEscape(id)
+}
@@ -0,0 +1,30 @@
+package todoclient
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem used for API calls. Its level can be
+// set on its own with TF_LOG_PROVIDER_TODOCLIENT.
+const logSubsystem = "todoclient"
+
+// credentialPattern matches credentials embedded in header values.
+var credentialPattern = regexp.MustCompile(`(?i)\b(basic|bearer)\s+\S+`)
+
+// logContext returns ctx with the client log subsystem set up, masking
+// anything that could leak credentials.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "base_url", c.baseURL)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "password", "token", "authorization")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, logSubsystem, credentialPattern)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, logSubsystem, credentialPattern)
+	if c.password != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, c.password)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, c.password)
+	}
+	return ctx
+}
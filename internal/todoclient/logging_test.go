@@ -0,0 +1,88 @@
+package todoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// echoHandler answers every request with an item whose title and
+// description repeat the credentials the request carried, as a careless
+// server echoing its input would.
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/login" {
+		_, _ = w.Write([]byte(`{"token":"secret-token","expires_in":3600}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode([]Item{{
+		ID:          "1",
+		Title:       "Authorization: " + r.Header.Get("Authorization"),
+		Description: "password=s3cr3t-pass",
+	}})
+}
+
+func TestClient_logMasksCredentials(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_TODOCLIENT", "TRACE")
+
+	for name, tc := range map[string]struct {
+		cfg    Config
+		secret []string
+	}{
+		"basic auth": {
+			cfg: Config{Username: "user", Password: "s3cr3t-pass"},
+			// base64 of user:s3cr3t-pass
+			secret: []string{"s3cr3t-pass", "Basic dXNlcjpzM2NyM3QtcGFzcw==", "dXNlcjpzM2NyM3QtcGFzcw=="},
+		},
+		"token auth": {
+			cfg:    Config{Username: "user", Password: "s3cr3t-pass", LoginURL: "/login"},
+			secret: []string{"s3cr3t-pass", "Bearer secret-token", "secret-token"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			c := newTestClient(t, echoHandler, tc.cfg)
+			if _, err := c.List(ctx); err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			item := Item{Title: "Log in with s3cr3t-pass", Description: "Authorization: Bearer secret-token"}
+			if _, err := c.Create(ctx, []Item{item}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("MultilineJSONDecode: %v", err)
+			}
+
+			var requestBodies, responseBodies int
+			for _, entry := range entries {
+				switch entry["@message"] {
+				case "Sending todo API request body":
+					requestBodies++
+				case "Received todo API response body":
+					responseBodies++
+				}
+
+				line, err := json.Marshal(entry)
+				if err != nil {
+					t.Fatalf("Marshal: %v", err)
+				}
+				for _, secret := range tc.secret {
+					if strings.Contains(string(line), secret) {
+						t.Errorf("log entry leaks %q: %s", secret, line)
+					}
+				}
+			}
+			if requestBodies == 0 || responseBodies == 0 {
+				t.Fatalf("expected request and response bodies to be logged at TRACE, got %d and %d entries", requestBodies, responseBodies)
+			}
+		})
+	}
+}
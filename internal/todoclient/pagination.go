@@ -0,0 +1,160 @@
+package todoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxResponseSize is the largest response body, in bytes, the
+// client reads when Config.MaxResponseSize is zero.
+const DefaultMaxResponseSize = 32 << 20
+
+// page is one response of the list endpoint. Servers that do not paginate
+// return a bare JSON array; paginating servers either return the array
+// with a Link header pointing at the next page, or wrap it in an object
+// whose next field is an opaque cursor sent back in the cursor parameter.
+type page struct {
+	Items []Item `json:"items"`
+	Next  string `json:"next"`
+}
+
+func (p *page) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		*p = page{}
+		return json.Unmarshal(trimmed, &p.Items)
+	}
+
+	// The alias drops this method so the object is decoded field by field
+	type envelope page
+	var v envelope
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*p = page(v)
+	return nil
+}
+
+// listAll fetches every page of the list endpoint for the given query
+// parameters and returns the items along with the headers of the first
+// page.
+func (c *Client) listAll(ctx context.Context, params url.Values) ([]Item, http.Header, error) {
+	params = cloneValues(params)
+	if c.pageSize > 0 {
+		params.Set("page_size", strconv.Itoa(c.pageSize))
+	}
+
+	path := "/get"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var (
+		items  []Item
+		header http.Header
+		seen   = map[string]bool{path: true}
+	)
+	for pages := 1; ; pages++ {
+		var p page
+		h, err := c.doWithHeader(ctx, http.MethodGet, path, nil, &p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if header == nil {
+			header = h
+		}
+		items = append(items, p.Items...)
+
+		next, err := c.nextPage(path, h, p.Next, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if next == "" {
+			return items, header, nil
+		}
+		if seen[next] {
+			return nil, nil, fmt.Errorf("todo API returned page %s twice, aborting to avoid an endless loop", next)
+		}
+		seen[next] = true
+		path = next
+
+		tflog.SubsystemDebug(c.logContext(ctx), logSubsystem, "Fetching next page of the todo list", map[string]any{
+			"page":  pages + 1,
+			"items": len(items),
+		})
+	}
+}
+
+// nextPage returns the path of the page following the one fetched from
+// path, or an empty string on the last page. A Link header takes
+// precedence over a cursor in the body.
+func (c *Client) nextPage(path string, header http.Header, cursor string, params url.Values) (string, error) {
+	if link := nextLink(header); link != "" {
+		current, err := url.Parse(c.baseURL + path)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse page URL: %w", err)
+		}
+		next, err := current.Parse(link)
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link %q: %w", link, err)
+		}
+
+		// Never send credentials to a host other than the API
+		nextPath, ok := strings.CutPrefix(next.String(), c.baseURL)
+		if !ok || (nextPath != "" && !strings.HasPrefix(nextPath, "/")) {
+			return "", fmt.Errorf("next page link %q points outside of the todo API at %s", link, c.baseURL)
+		}
+		return nextPath, nil
+	}
+
+	if cursor == "" {
+		return "", nil
+	}
+	params = cloneValues(params)
+	params.Set("cursor", cursor)
+	return strings.SplitN(path, "?", 2)[0] + "?" + params.Encode(), nil
+}
+
+// nextLink returns the target of the rel="next" entry of a Link header as
+// defined by RFC 8288, or an empty string.
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func cloneValues(v url.Values) url.Values {
+	clone := make(url.Values, len(v))
+	for key, values := range v {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
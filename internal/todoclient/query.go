@@ -0,0 +1,164 @@
+package todoclient
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Sort orders accepted by Query.SortBy. Every order is ascending and
+// stable, so items comparing equal keep their server order.
+const (
+	SortByServer   = "server"
+	SortByTitle    = "title"
+	SortByPriority = "priority"
+	SortByDueAt    = "due_at"
+)
+
+// QueryAppliedHeader is set to "true" by servers that filtered, sorted and
+// paged the list according to the query parameters of the request. Without
+// it the client assumes the whole list was returned and applies the query
+// itself.
+const QueryAppliedHeader = "X-Todo-Query-Applied"
+
+// Query selects a subset of the todo list. Filters are combined with a
+// logical AND; the zero value selects every item in server order.
+type Query struct {
+	// Title keeps items whose title is exactly Title.
+	Title string
+
+	// TitlePrefix keeps items whose title starts with TitlePrefix.
+	TitlePrefix string
+
+	// TitleRegex keeps items whose title matches the RE2 regular
+	// expression TitleRegex.
+	TitleRegex string
+
+	// Tag keeps items carrying the tag Tag.
+	Tag string
+
+	// Done, when not nil, keeps items whose done flag equals *Done.
+	Done *bool
+
+	// SortBy is one of the SortBy* constants; empty keeps server order.
+	// Items without a due date sort last with SortByDueAt.
+	SortBy string
+
+	// Offset skips that many items after filtering and sorting, and Limit,
+	// when positive, caps the number of items returned.
+	Offset int
+	Limit  int
+}
+
+// values encodes q as the query parameters of the list endpoint.
+func (q Query) values() url.Values {
+	v := url.Values{}
+	if q.Title != "" {
+		v.Set("title", q.Title)
+	}
+	if q.TitlePrefix != "" {
+		v.Set("title_prefix", q.TitlePrefix)
+	}
+	if q.TitleRegex != "" {
+		v.Set("title_regex", q.TitleRegex)
+	}
+	if q.Tag != "" {
+		v.Set("tag", q.Tag)
+	}
+	if q.Done != nil {
+		v.Set("done", strconv.FormatBool(*q.Done))
+	}
+	if q.SortBy != "" && q.SortBy != SortByServer {
+		v.Set("sort", q.SortBy)
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
+
+// apply filters, sorts and pages items locally.
+func (q Query) apply(items []Item) ([]Item, error) {
+	var pattern *regexp.Regexp
+	if q.TitleRegex != "" {
+		var err error
+		pattern, err = regexp.Compile(q.TitleRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title regular expression: %w", err)
+		}
+	}
+
+	result := make([]Item, 0, len(items))
+	for _, item := range items {
+		switch {
+		case q.Title != "" && item.Title != q.Title,
+			q.TitlePrefix != "" && !strings.HasPrefix(item.Title, q.TitlePrefix),
+			pattern != nil && !pattern.MatchString(item.Title),
+			q.Tag != "" && !slices.Contains(item.Tags, q.Tag),
+			q.Done != nil && item.Done != *q.Done:
+			continue
+		}
+		result = append(result, item)
+	}
+
+	switch q.SortBy {
+	case "", SortByServer:
+	case SortByTitle:
+		slices.SortStableFunc(result, func(a, b Item) int { return strings.Compare(a.Title, b.Title) })
+	case SortByPriority:
+		slices.SortStableFunc(result, func(a, b Item) int { return cmp.Compare(a.Priority, b.Priority) })
+	case SortByDueAt:
+		slices.SortStableFunc(result, func(a, b Item) int {
+			switch {
+			case a.DueAt == nil && b.DueAt == nil:
+				return 0
+			case a.DueAt == nil:
+				return 1
+			case b.DueAt == nil:
+				return -1
+			}
+			return a.DueAt.Compare(*b.DueAt)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported sort order %q", q.SortBy)
+	}
+
+	result = result[min(q.Offset, len(result)):]
+	if q.Limit > 0 && q.Limit < len(result) {
+		result = result[:q.Limit]
+	}
+	return result, nil
+}
+
+// Query returns the items of the todo list selected by q, following
+// pagination. The query is sent to the server, and applied by the client
+// when the first page does not carry QueryAppliedHeader.
+func (c *Client) Query(ctx context.Context, q Query) ([]Item, error) {
+	// Reject an invalid query before sending it
+	if _, err := q.apply(nil); err != nil {
+		return nil, err
+	}
+
+	items, header, err := c.listAll(ctx, q.values())
+	if err != nil {
+		return nil, err
+	}
+	if header.Get(QueryAppliedHeader) == "true" {
+		return items, nil
+	}
+
+	tflog.SubsystemDebug(c.logContext(ctx), logSubsystem, "Todo API did not apply the query, filtering the list locally", map[string]any{
+		"items": len(items),
+	})
+	return q.apply(items)
+}
@@ -0,0 +1,116 @@
+package todoclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings used by the provider when none are configured.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// isIdempotent reports whether a request with the given method can be sent
+// again without changing the outcome.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a request that ended with res or err should
+// be sent again. Too Many Requests means the server did not process the
+// request, so it is retried for every method. Server errors and transport
+// failures are only retried for idempotent methods.
+func shouldRetry(ctx context.Context, method string, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			return isRetryableStatus(method, apiErr.StatusCode)
+		}
+		return isIdempotent(method)
+	}
+
+	return isRetryableStatus(method, res.StatusCode)
+}
+
+func isRetryableStatus(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode >= 500 && statusCode != http.StatusNotImplemented && isIdempotent(method)
+}
+
+// backoff returns how long to wait before retry number attempt (starting at
+// zero). A Retry-After header sent with res takes precedence; otherwise the
+// wait doubles with every attempt and is jittered to avoid many clients
+// retrying in lockstep. The result never exceeds c.retryWaitMax.
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res); ok {
+			return min(wait, c.retryWaitMax)
+		}
+	}
+
+	wait := c.retryWaitMin << attempt
+	if wait <= 0 || wait > c.retryWaitMax {
+		wait = c.retryWaitMax
+	}
+
+	// Equal jitter: wait somewhere between half and all of the backoff
+	half := wait / 2
+	if half > 0 {
+		wait = half + rand.N(half)
+	}
+	return max(wait, c.retryWaitMin)
+}
+
+// retryAfter parses the Retry-After header of res, which holds either a
+// number of seconds or an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drain discards the rest of the body of res and closes it so the
+// underlying connection can be reused for the next attempt.
+func drain(res *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorBodySize))
+	res.Body.Close()
+}
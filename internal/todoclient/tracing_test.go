@@ -0,0 +1,76 @@
+package todoclient
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"terraform-provider-custom-example/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordSpans installs a global tracer provider recording the spans in
+// memory and the W3C trace context propagator for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+		_ = tp.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func TestClient_tracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var traceparent atomic.Value
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent.Store(r.Header.Get("traceparent"))
+		listHandler(w, r)
+	}, Config{})
+
+	ctx, span := tracing.Start(context.Background(), "customexample_add_todo_items.Read")
+	if _, err := c.List(ctx); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	span.End()
+	parent := span.SpanContext()
+
+	// The header carries the trace of the operation and the HTTP span as
+	// parent of the server side
+	header, _ := traceparent.Load().(string)
+	remote := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(),
+		propagation.HeaderCarrier{"Traceparent": []string{header}}))
+	if !remote.IsValid() || remote.TraceID() != parent.TraceID() {
+		t.Fatalf("expected a traceparent header in trace %s, got %q", parent.TraceID(), header)
+	}
+
+	var httpSpan sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.SpanKind() == trace.SpanKindClient {
+			httpSpan = s
+		}
+	}
+	if httpSpan == nil {
+		t.Fatal("expected a client span for the HTTP request")
+	}
+	if got := httpSpan.Parent().SpanID(); got != parent.SpanID() {
+		t.Errorf("expected the HTTP span to be a child of the operation span %s, got parent %s", parent.SpanID(), got)
+	}
+	if got := httpSpan.SpanContext().SpanID(); got != remote.SpanID() {
+		t.Errorf("expected traceparent to name the HTTP span %s, got %s", got, remote.SpanID())
+	}
+}
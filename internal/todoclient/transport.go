@@ -0,0 +1,130 @@
+package todoclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLSConfig holds the TLS settings used to connect to the API.
+type TLSConfig struct {
+	// CACertPEM holds PEM encoded certificates trusted in addition to the
+	// system roots.
+	CACertPEM []byte
+
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded certificate and
+	// private key presented to the server for mutual TLS. Both or neither
+	// must be set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// newTransport returns a copy of the default transport configured for cfg.
+func newTransport(cfg Config) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	proxy, err := newProxyFunc(cfg.ProxyURL, cfg.NoProxy)
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxy
+
+	// Record a client span per request and send the trace context in a
+	// traceparent header; both are no-ops unless tracing is enabled.
+	traced := otelhttp.NewTransport(transport)
+
+	if len(cfg.Headers) == 0 {
+		return traced, nil
+	}
+	return &headerTransport{
+		base:    traced,
+		headers: cfg.Headers,
+	}, nil
+}
+
+// newProxyFunc returns the proxy selection used by the transport. It starts
+// from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables;
+// proxyURL replaces both proxies and noProxy replaces the exclusion list
+// when they are not empty. Requests to localhost never use a proxy.
+func newProxyFunc(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+
+	if proxyURL != "" {
+		if _, err := url.Parse(proxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		proxyConfig.HTTPProxy = proxyURL
+		proxyConfig.HTTPSProxy = proxyURL
+	}
+	if noProxy != "" {
+		proxyConfig.NoProxy = noProxy
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// headerTransport sets a fixed set of headers on every request before
+// handing it to base.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+func newTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, fmt.Errorf("no valid PEM certificate found in the CA certificate bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (len(cfg.ClientCertPEM) > 0) != (len(cfg.ClientKeyPEM) > 0) {
+		return nil, fmt.Errorf("client certificate and client key must be set together")
+	}
+	if len(cfg.ClientCertPEM) > 0 {
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,73 @@
+package todoserver
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Faults configures failures injected in front of a Server to exercise the
+// retry and timeout handling of clients. Rates are probabilities between 0
+// and 1 applied independently to every request.
+type Faults struct {
+	// Latency is added to every request before it is handled.
+	Latency time.Duration
+
+	// ErrorRate is the share of requests answered with 500.
+	ErrorRate float64
+
+	// ThrottleRate is the share of requests answered with 429 and a
+	// Retry-After header of RetryAfter, rounded up to whole seconds.
+	ThrottleRate float64
+	RetryAfter   time.Duration
+
+	// TimeoutRate is the share of requests that hang until the client gives
+	// up or HangFor elapses, after which they are answered with 504.
+	TimeoutRate float64
+	HangFor     time.Duration
+}
+
+// WithFaults wraps next so that it misbehaves as configured by f.
+func WithFaults(next http.Handler, f Faults) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.Latency > 0 && !wait(r, f.Latency) {
+			return
+		}
+
+		switch {
+		case f.TimeoutRate > 0 && rand.Float64() < f.TimeoutRate:
+			if wait(r, f.HangFor) {
+				writeError(w, http.StatusGatewayTimeout, "timeout", "injected timeout", nil)
+			}
+		case f.ThrottleRate > 0 && rand.Float64() < f.ThrottleRate:
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(f.RetryAfter)))
+			writeError(w, http.StatusTooManyRequests, "rate_limited", "injected rate limit", nil)
+		case f.ErrorRate > 0 && rand.Float64() < f.ErrorRate:
+			writeError(w, http.StatusInternalServerError, "internal", "injected server error", nil)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// wait sleeps for d and reports whether the client is still waiting for a
+// response.
+func wait(r *http.Request, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-r.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryAfterSeconds converts d to the whole seconds of a Retry-After
+// header. Sub-second durations are rounded up so clients still back off.
+func retryAfterSeconds(d time.Duration) int {
+	return max(0, int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,48 @@
+package todoserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithFaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		faults         Faults
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		"none": {
+			wantStatus: http.StatusOK,
+		},
+		"error": {
+			faults:     Faults{ErrorRate: 1},
+			wantStatus: http.StatusInternalServerError,
+		},
+		"throttle": {
+			faults:         Faults{ThrottleRate: 1, RetryAfter: 2 * time.Second},
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+		"throttle below a second": {
+			faults:         Faults{ThrottleRate: 1, RetryAfter: 500 * time.Millisecond},
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: "1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := WithFaults(New(Options{}), tc.faults)
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/get", nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tc.wantRetryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tc.wantRetryAfter, got)
+			}
+		})
+	}
+}
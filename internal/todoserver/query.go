@@ -0,0 +1,101 @@
+package todoserver
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// queryAppliedHeader tells clients the list was filtered, sorted and paged
+// according to the query parameters.
+const queryAppliedHeader = "X-Todo-Query-Applied"
+
+// filterItems applies the query parameters of GET /get to items:
+// title, title_prefix, title_regex, tag and done filter, sort is one of
+// server, title, priority or due_at, and offset and limit page the result.
+func filterItems(items []Item, q url.Values) ([]Item, error) {
+	var pattern *regexp.Regexp
+	if expr := q.Get("title_regex"); expr != "" {
+		var err error
+		if pattern, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid title_regex: %w", err)
+		}
+	}
+
+	var done *bool
+	if v := q.Get("done"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid done: %w", err)
+		}
+		done = &b
+	}
+
+	offset, err := intParam(q, "offset")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intParam(q, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	title, prefix, tag := q.Get("title"), q.Get("title_prefix"), q.Get("tag")
+	result := make([]Item, 0, len(items))
+	for _, item := range items {
+		switch {
+		case title != "" && item.Title != title,
+			prefix != "" && !strings.HasPrefix(item.Title, prefix),
+			pattern != nil && !pattern.MatchString(item.Title),
+			tag != "" && !slices.Contains(item.Tags, tag),
+			done != nil && item.Done != *done:
+			continue
+		}
+		result = append(result, item)
+	}
+
+	switch sort := q.Get("sort"); sort {
+	case "", "server":
+	case "title":
+		slices.SortStableFunc(result, func(a, b Item) int { return strings.Compare(a.Title, b.Title) })
+	case "priority":
+		slices.SortStableFunc(result, func(a, b Item) int { return cmp.Compare(a.Priority, b.Priority) })
+	case "due_at":
+		// Items without a due date come last
+		slices.SortStableFunc(result, func(a, b Item) int {
+			switch {
+			case a.DueAt == nil && b.DueAt == nil:
+				return 0
+			case a.DueAt == nil:
+				return 1
+			case b.DueAt == nil:
+				return -1
+			}
+			return a.DueAt.Compare(*b.DueAt)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported sort %q", sort)
+	}
+
+	result = result[min(offset, len(result)):]
+	if limit > 0 && limit < len(result) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func intParam(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: must be a non-negative integer", name)
+	}
+	return n, nil
+}
@@ -0,0 +1,583 @@
+// Package todoserver implements the todo list API the custom example
+// provider talks to. It keeps the list in memory, optionally persisted to a
+// file, and backs both the provider tests and the cmd/todo-server reference
+// binary; it is not meant to be a production service.
+package todoserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Item is a todo stored by the server.
+type Item struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Done        bool       `json:"done"`
+	Priority    int64      `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+// Options configures a Server.
+type Options struct {
+	// Username and Password, when Username is not empty, are required on
+	// every request, either as HTTP Basic credentials or exchanged for a
+	// bearer token at /login.
+	Username string
+	Password string
+
+	// DisableQuery makes GET /get ignore its query parameters and return
+	// the whole list, like servers predating filtering.
+	DisableQuery bool
+
+	// PageSize, when positive, paginates GET /get even when the client
+	// does not send a page_size parameter.
+	PageSize int
+
+	// LinkPagination announces the next page in a Link header of a bare
+	// array response instead of a {"items", "next"} envelope.
+	LinkPagination bool
+
+	// TitleTransform, when not nil, rewrites the title of every item
+	// written through the API, like servers trimming or lowercasing
+	// titles.
+	TitleTransform func(string) string
+
+	// MaxTitleLength, when positive, rejects items whose title has more
+	// characters.
+	MaxTitleLength int
+
+	// BareTitles returns lists as arrays of titles instead of item
+	// objects, like servers predating item IDs.
+	BareTitles bool
+}
+
+// Server is an http.Handler serving the todo API.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu       sync.Mutex
+	items    []Item
+	archived []Item
+	nextID   int
+	tokens   map[string]bool
+	dataFile string
+}
+
+// errorBody is the JSON payload sent with every error response.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Items   []itemError `json:"items,omitempty"`
+}
+
+type itemError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// New returns a Server with an empty todo list.
+func New(opts Options) *Server {
+	s := &Server{
+		opts:   opts,
+		mux:    http.NewServeMux(),
+		tokens: map[string]bool{},
+	}
+
+	s.mux.HandleFunc("POST /login", s.handleLogin)
+	s.mux.HandleFunc("GET /get", s.authenticated(s.handleList))
+	s.mux.HandleFunc("POST /create", s.authenticated(s.handleCreate))
+	s.mux.HandleFunc("PUT /update", s.authenticated(s.handleReplace))
+	s.mux.HandleFunc("DELETE /delete", s.authenticated(s.handleDeleteAll))
+	s.mux.HandleFunc("POST /validate", s.authenticated(s.handleValidate))
+	s.mux.HandleFunc("POST /items", s.authenticated(s.handleCreateItem))
+	s.mux.HandleFunc("GET /items/{id}", s.authenticated(s.handleGetItem))
+	s.mux.HandleFunc("PUT /items/{id}", s.authenticated(s.handleUpdateItem))
+	s.mux.HandleFunc("DELETE /items/{id}", s.authenticated(s.handleDeleteItem))
+	s.mux.HandleFunc("POST /items/{id}/archive", s.authenticated(s.handleArchiveItem))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Titles returns the titles of every item in list order.
+func (s *Server) Titles() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.titles()
+}
+
+// Items returns a copy of every item in list order.
+func (s *Server) Items() []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Item(nil), s.items...)
+}
+
+// Archived returns a copy of every archived item in the order they were
+// archived.
+func (s *Server) Archived() []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Item(nil), s.archived...)
+}
+
+// SetTitles replaces the todo list with items holding only a title,
+// simulating a change made outside of Terraform.
+func (s *Server) SetTitles(titles ...string) {
+	items := make([]Item, 0, len(titles))
+	for _, title := range titles {
+		items = append(items, Item{Title: title})
+	}
+	s.SetItems(items...)
+}
+
+// SetItems replaces the todo list, simulating a change made outside of
+// Terraform. The items are given new IDs.
+func (s *Server) SetItems(items ...Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = nil
+	s.appendItems(items)
+	_ = s.saveLocked()
+}
+
+// DeleteItem removes the item with the given ID, simulating another client
+// deleting it.
+func (s *Server) DeleteItem(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.indexOf(id); i >= 0 {
+		s.items = append(s.items[:i], s.items[i+1:]...)
+		_ = s.saveLocked()
+	}
+}
+
+// UpdateItem replaces the item with the ID of item, simulating another
+// client editing it.
+func (s *Server) UpdateItem(item Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.indexOf(item.ID); i >= 0 {
+		s.items[i] = item
+		_ = s.saveLocked()
+	}
+}
+
+// AddItems appends items under new IDs, simulating another client adding
+// items to the list.
+func (s *Server) AddItems(items ...Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.appendItems(items)
+	_ = s.saveLocked()
+}
+
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.opts.Username == "" {
+			next(w, r)
+			return
+		}
+
+		if username, password, ok := r.BasicAuth(); ok {
+			if username == s.opts.Username && password == s.opts.Password {
+				next(w, r)
+				return
+			}
+		}
+
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			s.mu.Lock()
+			valid := s.tokens[token]
+			s.mu.Unlock()
+			if valid {
+				next(w, r)
+				return
+			}
+		}
+
+		writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid credentials", nil)
+	}
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var creds struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &creds) {
+		return
+	}
+	if creds.Username != s.opts.Username || creds.Password != s.opts.Password {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid username or password", nil)
+		return
+	}
+
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token":      token,
+		"expires_in": 3600,
+	})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	items := s.Items()
+	if !s.opts.DisableQuery {
+		var err error
+		items, err = filterItems(items, q)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_query", err.Error(), nil)
+			return
+		}
+		w.Header().Set(queryAppliedHeader, "true")
+	}
+
+	pageSize, err := intParam(q, "page_size")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error(), nil)
+		return
+	}
+	if pageSize == 0 {
+		pageSize = s.opts.PageSize
+	}
+	cursor, err := intParam(q, "cursor")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error(), nil)
+		return
+	}
+	if pageSize == 0 && cursor == 0 {
+		writeJSON(w, http.StatusOK, s.listBody(items))
+		return
+	}
+
+	// The cursor is the position of the first item of the page
+	start := min(cursor, len(items))
+	end := len(items)
+	if pageSize > 0 {
+		end = min(start+pageSize, len(items))
+	}
+	var next string
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+
+	if s.opts.LinkPagination {
+		if next != "" {
+			q.Set("cursor", next)
+			w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, q.Encode()))
+		}
+		writeJSON(w, http.StatusOK, s.listBody(items[start:end]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items": s.listBody(items[start:end]),
+		"next":  next,
+	})
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var items []Item
+	if !decode(w, r, &items) || !s.validate(w, items) {
+		return
+	}
+
+	s.mu.Lock()
+	s.appendItems(items)
+	result := append([]Item(nil), s.items...)
+	err := s.saveLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.listBody(result))
+}
+
+func (s *Server) handleReplace(w http.ResponseWriter, r *http.Request) {
+	var items []Item
+	if !decode(w, r, &items) || !s.validate(w, items) {
+		return
+	}
+
+	s.mu.Lock()
+	s.items = nil
+	s.appendItems(items)
+	result := append([]Item(nil), s.items...)
+	err := s.saveLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.listBody(result))
+}
+
+// listBody returns the response body listing items, as bare titles when
+// the server is configured so.
+func (s *Server) listBody(items []Item) any {
+	if !s.opts.BareTitles {
+		return items
+	}
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func (s *Server) handleDeleteAll(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	s.items = nil
+	err := s.saveLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, []Item{})
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var items []Item
+	if !decode(w, r, &items) || !s.validate(w, items) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleCreateItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	if !decode(w, r, &item) || !s.validate(w, []Item{item}) {
+		return
+	}
+
+	s.mu.Lock()
+	item = s.appendItems([]Item{item})[0]
+	err := s.saveLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleGetItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	i := s.indexOf(r.PathValue("id"))
+	var item Item
+	if i >= 0 {
+		item = s.items[i]
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "todo item not found", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleUpdateItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	if !decode(w, r, &item) || !s.validate(w, []Item{item}) {
+		return
+	}
+
+	s.mu.Lock()
+	i := s.indexOf(r.PathValue("id"))
+	var err error
+	if i >= 0 {
+		item.ID = s.items[i].ID
+		item.Title = s.transformTitle(item.Title)
+		s.items[i] = item
+		err = s.saveLocked()
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "todo item not found", nil)
+		return
+	}
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleDeleteItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	i := s.indexOf(r.PathValue("id"))
+	var err error
+	if i >= 0 {
+		s.items = append(s.items[:i], s.items[i+1:]...)
+		err = s.saveLocked()
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "todo item not found", nil)
+		return
+	}
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleArchiveItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	i := s.indexOf(r.PathValue("id"))
+	var (
+		item Item
+		err  error
+	)
+	if i >= 0 {
+		item = s.items[i]
+		s.items = append(s.items[:i], s.items[i+1:]...)
+		s.archived = append(s.archived, item)
+		err = s.saveLocked()
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "todo item not found", nil)
+		return
+	}
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+// appendItems stores items under new IDs and returns them. The caller
+// must hold s.mu.
+func (s *Server) appendItems(items []Item) []Item {
+	added := make([]Item, 0, len(items))
+	for _, item := range items {
+		s.nextID++
+		item.ID = strconv.Itoa(s.nextID)
+		item.Title = s.transformTitle(item.Title)
+		s.items = append(s.items, item)
+		added = append(added, item)
+	}
+	return added
+}
+
+// transformTitle applies Options.TitleTransform to title.
+func (s *Server) transformTitle(title string) string {
+	if s.opts.TitleTransform == nil {
+		return title
+	}
+	return s.opts.TitleTransform(title)
+}
+
+// titles returns the title of every item. The caller must hold s.mu.
+func (s *Server) titles() []string {
+	titles := make([]string, 0, len(s.items))
+	for _, item := range s.items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+// indexOf returns the position of the item with the given ID, or -1. The
+// caller must hold s.mu.
+func (s *Server) indexOf(id string) int {
+	for i, item := range s.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// validate rejects blank and, with Options.MaxTitleLength, overly long
+// titles with a 422 listing every offending index.
+func (s *Server) validate(w http.ResponseWriter, items []Item) bool {
+	var errs []itemError
+	for i, item := range items {
+		switch {
+		case strings.TrimSpace(item.Title) == "":
+			errs = append(errs, itemError{Index: i, Message: "title must not be blank"})
+		case s.opts.MaxTitleLength > 0 && utf8.RuneCountInString(item.Title) > s.opts.MaxTitleLength:
+			errs = append(errs, itemError{Index: i, Message: fmt.Sprintf("title must be at most %d characters", s.opts.MaxTitleLength)})
+		}
+	}
+	if len(errs) == 0 {
+		return true
+	}
+
+	writeError(w, http.StatusUnprocessableEntity, "validation_failed", fmt.Sprintf("%d invalid item(s)", len(errs)), errs)
+	return false
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", err.Error(), nil)
+		return false
+	}
+	return true
+}
+
+func writeStorageError(w http.ResponseWriter, err error) {
+	writeError(w, http.StatusInternalServerError, "storage_error", err.Error(), nil)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, items []itemError) {
+	writeJSON(w, status, errorBody{
+		Error: errorDetail{
+			Code:    code,
+			Message: message,
+			Items:   items,
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package todoserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// snapshot is the on-disk representation of the todo list.
+type snapshot struct {
+	NextID   int    `json:"next_id"`
+	Items    []Item `json:"items"`
+	Archived []Item `json:"archived,omitempty"`
+}
+
+// Open returns a Server whose todo list is loaded from and saved to
+// dataFile after every change. A missing file starts an empty list.
+func Open(dataFile string, opts Options) (*Server, error) {
+	s := New(opts)
+	s.dataFile = dataFile
+
+	data, err := os.ReadFile(dataFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read data file: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("unable to parse data file %s: %w", dataFile, err)
+	}
+	s.items = snap.Items
+	s.archived = snap.Archived
+	s.nextID = snap.NextID
+
+	return s, nil
+}
+
+// saveLocked writes the todo list to the data file, if any. The file is
+// replaced atomically so a crash never leaves it half written. The caller
+// must hold s.mu.
+func (s *Server) saveLocked() error {
+	if s.dataFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(snapshot{
+		NextID:   s.nextID,
+		Items:    s.items,
+		Archived: s.archived,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode todo list: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.dataFile), filepath.Base(s.dataFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to save todo list: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to save todo list: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to save todo list: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.dataFile); err != nil {
+		return fmt.Errorf("unable to save todo list: %w", err)
+	}
+	return nil
+}
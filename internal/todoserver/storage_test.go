@@ -0,0 +1,80 @@
+package todoserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// serve sends a request to s and decodes the JSON response into out.
+func serve(t *testing.T, s *Server, method, path, body string, out any) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s %s: unexpected status %d: %s", method, path, rec.Code, rec.Body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: unable to decode response: %v", method, path, err)
+		}
+	}
+}
+
+func titles(items []Item) []string {
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestOpen_persistence(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "todos.json")
+
+	s, err := Open(dataFile, Options{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	serve(t, s, http.MethodPost, "/create", `[{"title":"A","done":true,"tags":["x"]},{"title":"B"},{"title":"C"}]`, nil)
+	var archived Item
+	serve(t, s, http.MethodPost, "/items/2/archive", "", &archived)
+
+	s, err = Open(dataFile, Options{})
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+
+	items := s.Items()
+	if want := []string{"A", "C"}; !slices.Equal(titles(items), want) {
+		t.Errorf("expected items %q, got %q", want, titles(items))
+	}
+	if !items[0].Done || !slices.Equal(items[0].Tags, []string{"x"}) {
+		t.Errorf("expected the fields of A to be kept, got %+v", items[0])
+	}
+	if got := s.Archived(); len(got) != 1 || got[0].ID != archived.ID || got[0].Title != "B" {
+		t.Errorf("expected B in the archive, got %+v", got)
+	}
+
+	// IDs keep counting from the saved next_id
+	var created Item
+	serve(t, s, http.MethodPost, "/items", `{"title":"D"}`, &created)
+	if created.ID != "4" {
+		t.Errorf("expected the new item to get ID 4, got %q", created.ID)
+	}
+}
+
+func TestOpen_missingFile(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "todos.json"), Options{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if items := s.Items(); len(items) != 0 {
+		t.Errorf("expected an empty list, got %+v", items)
+	}
+}
@@ -0,0 +1,121 @@
+// Package tracing sets up optional OpenTelemetry tracing for the provider.
+//
+// Tracing is disabled unless CUSTOM_EXAMPLE_TRACING_EXPORTER is set:
+//
+//   - "otlp" exports spans over OTLP/HTTP, configured with the standard
+//     OTEL_EXPORTER_OTLP_* environment variables.
+//   - "file" writes spans as JSON to CUSTOM_EXAMPLE_TRACING_FILE, or to
+//     terraform-provider-customexample-traces.json in the working directory.
+//
+// Outbound requests carry a W3C traceparent header so the todo API can join
+// its spans to the provider's.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ServiceName identifies the provider in exported traces.
+	ServiceName = "terraform-provider-customexample"
+
+	defaultTraceFile = "terraform-provider-customexample-traces.json"
+)
+
+// Setup installs the global tracer provider selected by the environment
+// and returns a function flushing and stopping it. When tracing is disabled
+// it installs nothing and the returned function is a no-op.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   func() error
+		err      error
+	)
+	switch kind := os.Getenv("CUSTOM_EXAMPLE_TRACING_EXPORTER"); kind {
+	case "":
+		return noop, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+		if err != nil {
+			return noop, fmt.Errorf("unable to create OTLP trace exporter: %w", err)
+		}
+	case "file":
+		path := os.Getenv("CUSTOM_EXAMPLE_TRACING_FILE")
+		if path == "" {
+			path = defaultTraceFile
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return noop, fmt.Errorf("unable to open trace file: %w", err)
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return noop, fmt.Errorf("unable to create file trace exporter: %w", err)
+		}
+		closer = f.Close
+	default:
+		return noop, fmt.Errorf("unsupported CUSTOM_EXAMPLE_TRACING_EXPORTER %q, expected \"otlp\" or \"file\"", kind)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer())
+		}
+		return err
+	}, nil
+}
+
+// Start starts a span for a provider operation, such as a resource Create,
+// as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(ServiceName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End marks span as failed when diags holds errors and ends it.
+func End(span trace.Span, diags diag.Diagnostics) {
+	if diags.HasError() {
+		for _, d := range diags.Errors() {
+			span.RecordError(errors.New(d.Summary() + ": " + d.Detail()))
+		}
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	span.End()
+}
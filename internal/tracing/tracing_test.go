@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// resetGlobals restores a no-op tracer provider and propagator once the
+// test is done, as Setup replaces the global ones.
+func resetGlobals(t *testing.T) {
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+}
+
+func TestSetup_disabled(t *testing.T) {
+	resetGlobals(t)
+	t.Setenv("CUSTOM_EXAMPLE_TRACING_EXPORTER", "")
+
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		t.Error("expected no tracer provider to be installed")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+}
+
+func TestSetup_file(t *testing.T) {
+	resetGlobals(t)
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("CUSTOM_EXAMPLE_TRACING_EXPORTER", "file")
+	t.Setenv("CUSTOM_EXAMPLE_TRACING_FILE", path)
+
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	diags.AddError("Unable to Create Todo Items", "rejected")
+	_, span := Start(context.Background(), "customexample_add_todo_items.Create")
+	End(span, diags)
+
+	// Shutting down flushes the batched spans to the file
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, want := range []string{`"customexample_add_todo_items.Create"`, ServiceName, "Unable to Create Todo Items"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected the trace file to contain %s, got %s", want, data)
+		}
+	}
+}
+
+func TestSetup_unsupportedExporter(t *testing.T) {
+	resetGlobals(t)
+	t.Setenv("CUSTOM_EXAMPLE_TRACING_EXPORTER", "zipkin")
+
+	shutdown, err := Setup(context.Background(), "test")
+	if err == nil || !strings.Contains(err.Error(), `unsupported CUSTOM_EXAMPLE_TRACING_EXPORTER "zipkin"`) {
+		t.Fatalf("expected an unsupported exporter error, got %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+}
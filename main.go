@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"terraform-provider-custom-example/internal/provider"
+	"terraform-provider-custom-example/internal/tracing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -25,7 +26,17 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	shutdownTracing, err := tracing.Setup(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Flush any buffered spans before the provider process exits
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		log.Printf("unable to flush traces: %s", shutdownErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())